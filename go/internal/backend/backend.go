@@ -0,0 +1,105 @@
+// Package backend abstracts over gnark's groth16 and plonk backends so a
+// circuit can be compiled, proved and verified under either one without
+// the caller needing to branch on proof system. RunEdDSA, RunECDSA and
+// hash.Run all hard-coded groth16; this lets the benchmark harness report
+// CRS/proof sizes for both.
+package backend
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+)
+
+// Kind identifies which proof system a circuit is compiled/proved under.
+type Kind int
+
+const (
+	Groth16 Kind = iota
+	Plonk
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Groth16:
+		return "groth16"
+	case Plonk:
+		return "plonk"
+	default:
+		return "unknown"
+	}
+}
+
+// Compile builds the constraint system for circuit under the given
+// backend Kind: R1CS for Groth16, SCS (sparse R1CS / PLONKish) for PLONK.
+func Compile(kind Kind, field *big.Int, circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	switch kind {
+	case Groth16:
+		return frontend.Compile(field, r1cs.NewBuilder, circuit)
+	case Plonk:
+		return frontend.Compile(field, scs.NewBuilder, circuit)
+	default:
+		return nil, errUnknownKind(kind)
+	}
+}
+
+// Setup runs the backend-specific setup: a Groth16 per-circuit trusted
+// setup, or a PLONK setup against a KZG SRS sized for ccs. The SRS is
+// built with test.NewKZGSRS, which is fine for benchmarking but is not a
+// trusted setup suitable for production use (a deployment would load a
+// Powers-of-Tau ceremony transcript instead).
+func Setup(kind Kind, ccs constraint.ConstraintSystem) (pk, vk any, err error) {
+	switch kind {
+	case Groth16:
+		return groth16.Setup(ccs)
+	case Plonk:
+		srs, srsLagrange, err := test.NewKZGSRS(ccs)
+		if err != nil {
+			return nil, nil, err
+		}
+		return plonk.Setup(ccs, srs, srsLagrange)
+	default:
+		return nil, nil, errUnknownKind(kind)
+	}
+}
+
+// Prove generates a proof for assignment under the given backend Kind.
+func Prove(kind Kind, ccs constraint.ConstraintSystem, pk any, fullWitness witness.Witness) (proof any, err error) {
+	switch kind {
+	case Groth16:
+		return groth16.Prove(ccs, pk.(groth16.ProvingKey), fullWitness)
+	case Plonk:
+		return plonk.Prove(ccs, pk.(plonk.ProvingKey), fullWitness)
+	default:
+		return nil, errUnknownKind(kind)
+	}
+}
+
+// Verify checks proof against vk and the public part of the witness.
+func Verify(kind Kind, proof any, vk any, publicWitness witness.Witness) error {
+	switch kind {
+	case Groth16:
+		return groth16.Verify(proof.(groth16.Proof), vk.(groth16.VerifyingKey), publicWitness)
+	case Plonk:
+		return plonk.Verify(proof.(plonk.Proof), vk.(plonk.VerifyingKey), publicWitness)
+	default:
+		return errUnknownKind(kind)
+	}
+}
+
+func errUnknownKind(kind Kind) error {
+	return &unknownKindError{kind}
+}
+
+type unknownKindError struct{ kind Kind }
+
+func (e *unknownKindError) Error() string {
+	return "backend: unknown Kind " + e.kind.String()
+}
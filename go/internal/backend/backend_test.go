@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+// squareCircuit proves knowledge of X such that X*X == Y, just enough to
+// exercise Compile/Setup/Prove/Verify under both backend Kinds.
+type squareCircuit struct {
+	X frontend.Variable `gnark:",secret"`
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestGroth16RoundTrip(t *testing.T) {
+	testRoundTrip(t, Groth16)
+}
+
+func TestPlonkRoundTrip(t *testing.T) {
+	testRoundTrip(t, Plonk)
+}
+
+func testRoundTrip(t *testing.T, kind Kind) {
+	field := ecc.BN254.ScalarField()
+
+	circuit := squareCircuit{}
+	ccs, err := Compile(kind, field, &circuit)
+	if err != nil {
+		t.Fatalf("%s: compile: %v", kind, err)
+	}
+
+	pk, vk, err := Setup(kind, ccs)
+	if err != nil {
+		t.Fatalf("%s: setup: %v", kind, err)
+	}
+
+	assignment := squareCircuit{X: 3, Y: 9}
+	fullWitness, err := frontend.NewWitness(&assignment, field)
+	if err != nil {
+		t.Fatalf("%s: witness: %v", kind, err)
+	}
+	publicWitness, err := fullWitness.Public()
+	if err != nil {
+		t.Fatalf("%s: public witness: %v", kind, err)
+	}
+
+	proof, err := Prove(kind, ccs, pk, fullWitness)
+	if err != nil {
+		t.Fatalf("%s: prove: %v", kind, err)
+	}
+	if err := Verify(kind, proof, vk, publicWitness); err != nil {
+		t.Fatalf("%s: verify: %v", kind, err)
+	}
+}
@@ -1,14 +1,16 @@
 package hash
 
 import (
-	"bytes"
 	"log"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"nizkp_benchmark/internal/bench"
 )
 
 // MiMCCircuit defines a pre-image knowledge proof
@@ -70,16 +72,20 @@ func Run() {
 	circuit := MiMCCircuit{
 		Constants: make([]frontend.Variable, MIMC_ROUNDS),
 	}
+	compileStart := time.Now()
 	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &circuit)
 	if err != nil {
 		log.Fatal(err)
 	}
+	compileDuration := time.Since(compileStart)
 
 	// groth16 zkSNARK: Setup
+	setupStart := time.Now()
 	pk, vk, err := groth16.Setup(ccs)
 	if err != nil {
 		log.Fatal(err)
 	}
+	setupDuration := time.Since(setupStart)
 
 	// witness definition
 	assignment := MiMCCircuit{
@@ -98,50 +104,25 @@ func Run() {
 	}
 
 	// groth16: Prove & Verify
+	proveStart := time.Now()
 	proof, err := groth16.Prove(ccs, pk, witness)
 	if err != nil {
 		log.Fatal(err)
 	}
-	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
-		log.Fatal(err)
-	}
+	proveDuration := time.Since(proveStart)
 
-	// Output CRS metrics
-	buf := new(bytes.Buffer)
-	sizeSerizalizedProvingKeyBytes, err := pk.WriteTo(buf)
-	if err != nil {
-		log.Fatal(err)
-	}
-	buf.Reset()
-	sizeSerizalizedVerificationKeyBytes, err := vk.WriteTo(buf)
-	if err != nil {
-		log.Fatal(err)
-	}
-	buf.Reset()
-	sizeSerizalizedRawProvingKeyBytes, err := pk.WriteRawTo(buf)
-	if err != nil {
-		log.Fatal(err)
-	}
-	buf.Reset()
-	sizeSerizalizedRawVerificationKeyBytes, err := vk.WriteRawTo(buf)
-	if err != nil {
+	verifyStart := time.Now()
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
 		log.Fatal(err)
 	}
-	buf.Reset()
-	log.Printf("CRS metrics:\n\tSize serialized proving key (bytes): %d compressed, %d uncompressed\n\tSize serialized verification key (bytes):  %d compressed, %d uncompressed\n", sizeSerizalizedProvingKeyBytes, sizeSerizalizedRawProvingKeyBytes, sizeSerizalizedVerificationKeyBytes, sizeSerizalizedRawVerificationKeyBytes)
+	verifyDuration := time.Since(verifyStart)
 
-	// Output proof metrics
-	sizeSerizalizedProofBytes, err := proof.WriteRawTo(buf)
-	if err != nil {
-		log.Fatal(err)
-	}
-	buf.Reset()
-	sizeSerizalizedRawProofBytes, err := proof.WriteRawTo(buf)
-	if err != nil {
-		log.Fatal(err)
-	}
-	buf.Reset()
-	log.Printf("Proof metrics:\n\tSize serialized (bytes): %d compressed, %d uncompressed \n\tSecurity level (bits): %s conjectured, %s proven", sizeSerizalizedProofBytes, sizeSerizalizedRawProofBytes, "?", "?")
+	bench.Report("MiMC", ccs, pk, vk, proof, bench.Timings{
+		Compile: compileDuration,
+		Setup:   setupDuration,
+		Prove:   proveDuration,
+		Verify:  verifyDuration,
+	})
 }
 
 func mimc(xl, xr fr.Element, constants []fr.Element) fr.Element {
@@ -0,0 +1,210 @@
+// Package zkcommit implements a Pedersen commitment gadget over the
+// twisted Edwards curve already used by EdDSACircuit, so a signed
+// message can be the hash of one or more hiding, binding commitments
+// rather than plaintext values - a building block for confidential
+// transaction style proofs on top of the existing signature circuits.
+package zkcommit
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	gnarkhash "github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+)
+
+// Commit computes C = blinding*H + Σ values_i*bases_i, a multi-value
+// Pedersen commitment under the independent basis vector bases and
+// blinding base H.
+func Commit(api frontend.API, curve twistededwards.Curve, h twistededwards.Point, bases []twistededwards.Point, values []frontend.Variable, blinding frontend.Variable) (twistededwards.Point, error) {
+	if len(bases) != len(values) {
+		return twistededwards.Point{}, errBasesValuesMismatch
+	}
+
+	c := curve.ScalarMul(h, blinding)
+	for i := range bases {
+		c = curve.Add(c, curve.ScalarMul(bases[i], values[i]))
+	}
+	return c, nil
+}
+
+// AssertOpening asserts that C is the Pedersen commitment to values under
+// bases/H with the given blinding factor, i.e. that the prover knows a
+// valid opening of C.
+func AssertOpening(api frontend.API, curve twistededwards.Curve, c twistededwards.Point, h twistededwards.Point, bases []twistededwards.Point, values []frontend.Variable, blinding frontend.Variable) error {
+	recomputed, err := Commit(api, curve, h, bases, values, blinding)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(recomputed.X, c.X)
+	api.AssertIsEqual(recomputed.Y, c.Y)
+	return nil
+}
+
+var errBasesValuesMismatch = commitError("zkcommit: bases and values must have the same length")
+
+type commitError string
+
+func (e commitError) Error() string { return string(e) }
+
+// Setup generates len(tags)+1 independent basis points - one blinding
+// base H followed by one base per tag - via hashToCurve on each
+// domain-separated tag, so no base is a publicly computable scalar
+// multiple of another: that is what makes Commit/AssertOpening binding
+// rather than a single-generator commitment in disguise.
+func Setup(curveID tedwards.ID, tags ...string) (h twistededwards.Point, bases []twistededwards.Point, err error) {
+	points := make([]twistededwards.Point, len(tags)+1)
+	for i, tag := range append([]string{"zkcommit/H"}, tags...) {
+		p, err := hashToCurve(curveID, tag)
+		if err != nil {
+			return twistededwards.Point{}, nil, err
+		}
+		points[i] = twistededwards.Point{X: p.X, Y: p.Y}
+	}
+	return points[0], points[1:], nil
+}
+
+// hashToCurve derives a nothing-up-my-sleeve point for tag by
+// try-and-increment: hash tag and an incrementing counter with SHA-256 -
+// a standard, widely-audited hash, not the SNARK-friendly MiMC used for
+// in-circuit challenges elsewhere in this package, since a generator
+// meant to have no known discrete-log relation to any other generator
+// must not rely on an algebraic hash's structure either - to a candidate
+// y-coordinate, solve the twisted Edwards equation
+// a*x^2 + y^2 = 1 + d*x^2*y^2 for x, and retry with the next counter
+// whenever y has no square-root x or cofactor-clearing the resulting
+// point collapses it to the identity. Because the hash has no known
+// preimage relationship to params.Base, nobody - including whoever
+// generated the tag list - learns the discrete log of the result with
+// respect to params.Base or any other tag's point.
+func hashToCurve(curveID tedwards.ID, tag string) (tedwards.PointAffine, error) {
+	baseField, err := twistededwards.GetSnarkField(curveID)
+	if err != nil {
+		return tedwards.PointAffine{}, err
+	}
+	params, err := tedwards.GetCurveParams(curveID)
+	if err != nil {
+		return tedwards.PointAffine{}, err
+	}
+
+	one := big.NewInt(1)
+	for counter := uint64(0); ; counter++ {
+		y := hashToField(tag, counter, baseField)
+
+		y2 := new(big.Int).Mul(y, y)
+		y2.Mod(y2, baseField)
+
+		num := new(big.Int).Sub(one, y2)
+		num.Mod(num, baseField)
+
+		den := new(big.Int).Mul(params.D, y2)
+		den.Sub(params.A, den)
+		den.Mod(den, baseField)
+		if den.Sign() == 0 {
+			continue
+		}
+		denInv := new(big.Int).ModInverse(den, baseField)
+		if denInv == nil {
+			continue
+		}
+
+		x2 := new(big.Int).Mul(num, denInv)
+		x2.Mod(x2, baseField)
+
+		x := new(big.Int).ModSqrt(x2, baseField)
+		if x == nil {
+			continue
+		}
+
+		var candidate tedwards.PointAffine
+		candidate.X.SetBigInt(x)
+		candidate.Y.SetBigInt(y)
+
+		var cleared tedwards.PointAffine
+		cleared.ScalarMultiplication(&candidate, params.Cofactor)
+
+		clearedX, clearedY := new(big.Int), new(big.Int)
+		cleared.X.BigInt(clearedX)
+		cleared.Y.BigInt(clearedY)
+		if clearedX.Sign() == 0 && clearedY.Cmp(one) == 0 {
+			// Candidate had no component in the prime-order subgroup;
+			// cofactor-clearing collapsed it to the identity.
+			continue
+		}
+
+		return cleared, nil
+	}
+}
+
+func hashToField(tag string, counter uint64, modulus *big.Int) *big.Int {
+	hasher := sha256.New()
+	hasher.Write([]byte(tag))
+	var counterBytes [8]byte
+	binary.BigEndian.PutUint64(counterBytes[:], counter)
+	hasher.Write(counterBytes[:])
+	v := new(big.Int).SetBytes(hasher.Sum(nil))
+	return v.Mod(v, modulus)
+}
+
+// ComputeOffCircuit recomputes, off-circuit, the commitment Commit would
+// produce for the given tags (the same tags passed to Setup), values and
+// blinding factor. Callers that called Setup to get the H/bases witness
+// for a circuit use this to get a consistent commitment to assign as the
+// circuit's public input.
+func ComputeOffCircuit(curveID tedwards.ID, tags []string, values []*big.Int, blinding *big.Int) (twistededwards.Point, error) {
+	if len(tags) != len(values) {
+		return twistededwards.Point{}, errBasesValuesMismatch
+	}
+
+	params, err := tedwards.GetCurveParams(curveID)
+	if err != nil {
+		return twistededwards.Point{}, err
+	}
+
+	h, err := hashToCurve(curveID, "zkcommit/H")
+	if err != nil {
+		return twistededwards.Point{}, err
+	}
+	var c tedwards.PointAffine
+	c.ScalarMultiplication(&h, new(big.Int).Mod(blinding, params.Order))
+
+	for i, tag := range tags {
+		base, err := hashToCurve(curveID, tag)
+		if err != nil {
+			return twistededwards.Point{}, err
+		}
+		var term tedwards.PointAffine
+		term.ScalarMultiplication(&base, new(big.Int).Mod(values[i], params.Order))
+		c.Add(&c, &term)
+	}
+
+	return twistededwards.Point{X: c.X, Y: c.Y}, nil
+}
+
+// bigIntable is satisfied by the concrete field-element type backing a
+// twistededwards.Point's X/Y coordinates once assigned from a
+// tedwards.PointAffine (as Setup/ComputeOffCircuit do), letting
+// DigestOffCircuit recover plain big.Ints from a witness-ready Point.
+type bigIntable interface {
+	BigInt(*big.Int) *big.Int
+}
+
+// DigestOffCircuit computes, off-circuit, the same MiMC digest that an
+// EdDSACircuit with Commitments set computes in-circuit:
+// MiMC(commitments[0].X, commitments[0].Y, ..., commitments[k].X,
+// commitments[k].Y). Callers use this to derive the Message a signer
+// signs over a set of commitments.
+func DigestOffCircuit(commitments ...twistededwards.Point) *big.Int {
+	hasher := gnarkhash.MIMC_BN254.New()
+	buf := make([]byte, 32)
+	for _, c := range commitments {
+		c.X.(bigIntable).BigInt(new(big.Int)).FillBytes(buf)
+		hasher.Write(buf)
+		c.Y.(bigIntable).BigInt(new(big.Int)).FillBytes(buf)
+		hasher.Write(buf)
+	}
+	return new(big.Int).SetBytes(hasher.Sum(nil))
+}
@@ -0,0 +1,101 @@
+package zkcommit
+
+import (
+	"math/big"
+	"testing"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+type commitCircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	H     twistededwards.Point   `gnark:",public"`
+	Bases []twistededwards.Point `gnark:",public"`
+	C     twistededwards.Point   `gnark:",public"`
+
+	Values   []frontend.Variable `gnark:",secret"`
+	Blinding frontend.Variable   `gnark:",secret"`
+}
+
+func (circuit *commitCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+	return AssertOpening(api, curve, circuit.C, circuit.H, circuit.Bases, circuit.Values, circuit.Blinding)
+}
+
+func TestPedersenCommitment(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := []string{"zkcommit-test/a", "zkcommit-test/b"}
+	h, bases, err := Setup(curve, tags...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []*big.Int{big.NewInt(7), big.NewInt(13)}
+	blinding := big.NewInt(99)
+
+	// Recompute the commitment off-circuit via the same hashToCurve
+	// bases Setup produced, the way a real caller would (rather than
+	// reimplementing the basis derivation here).
+	c, err := ComputeOffCircuit(curve, tags, values, blinding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	circuit := commitCircuit{
+		curveID: curve,
+		Bases:   make([]twistededwards.Point, len(bases)),
+	}
+	witnessAssignment := commitCircuit{
+		H:        h,
+		Bases:    bases,
+		C:        c,
+		Values:   []frontend.Variable{values[0], values[1]},
+		Blinding: blinding,
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestHashToCurveIndependentFromBase checks that hashToCurve's output is
+// not params.Base itself, i.e. that the tag was actually hashed to a new
+// point rather than the curve's base point being returned.
+func TestHashToCurveIndependentFromBase(t *testing.T) {
+	const curve = tedwards.BN254
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := hashToCurve(curve, "zkcommit-test/independence")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var insecure tedwards.PointAffine
+	insecure.ScalarMultiplication(&params.Base, big.NewInt(1))
+
+	pX, pY := new(big.Int), new(big.Int)
+	p.X.BigInt(pX)
+	p.Y.BigInt(pY)
+	insecureX, insecureY := new(big.Int), new(big.Int)
+	insecure.X.BigInt(insecureX)
+	insecure.Y.BigInt(insecureY)
+
+	if pX.Cmp(insecureX) == 0 && pY.Cmp(insecureY) == 0 {
+		t.Fatal("hashToCurve produced params.Base itself, suggesting no real hash-to-curve occurred")
+	}
+}
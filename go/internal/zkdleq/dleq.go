@@ -0,0 +1,66 @@
+// Package zkdleq implements an in-circuit Chaum-Pedersen discrete-log
+// equality (DLEQ) gadget over the twisted Edwards curve already used by
+// EdDSACircuit, plus an EdDSA adaptor-signature circuit built on top of
+// it.
+package zkdleq
+
+import (
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// AssertDLEQ proves, inside the SNARK, that the prover knows a scalar x
+// such that H1 = x*G1 and H2 = x*G2, following the standard Chaum-Pedersen
+// NIZK: given witness (x, r), compute A1 = r*G1, A2 = r*G2,
+// c = MiMC(G1, H1, G2, H2, A1, A2), s = r + c*x, and assert
+// s*G1 == A1 + c*H1 and s*G2 == A2 + c*H2.
+func AssertDLEQ(api frontend.API, curve twistededwards.Curve, g1, h1, g2, h2 twistededwards.Point, x, r frontend.Variable) error {
+	a1 := curve.ScalarMul(g1, r)
+	a2 := curve.ScalarMul(g2, r)
+
+	challengeHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	challengeHash.Write(g1.X, g1.Y, h1.X, h1.Y, g2.X, g2.Y, h2.X, h2.Y, a1.X, a1.Y, a2.X, a2.Y)
+	c := challengeHash.Sum()
+
+	s := api.Add(r, api.Mul(c, x))
+
+	lhs1 := curve.ScalarMul(g1, s)
+	rhs1 := curve.Add(a1, curve.ScalarMul(h1, c))
+	api.AssertIsEqual(lhs1.X, rhs1.X)
+	api.AssertIsEqual(lhs1.Y, rhs1.Y)
+
+	lhs2 := curve.ScalarMul(g2, s)
+	rhs2 := curve.Add(a2, curve.ScalarMul(h2, c))
+	api.AssertIsEqual(lhs2.X, rhs2.X)
+	api.AssertIsEqual(lhs2.Y, rhs2.Y)
+
+	return nil
+}
+
+// DLEQCircuit wraps AssertDLEQ as a standalone circuit, public inputs
+// being the two base/image pairs and the secret witness being (x, r).
+type DLEQCircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	G1 twistededwards.Point `gnark:",public"`
+	H1 twistededwards.Point `gnark:",public"`
+	G2 twistededwards.Point `gnark:",public"`
+	H2 twistededwards.Point `gnark:",public"`
+
+	X frontend.Variable `gnark:",secret"`
+	R frontend.Variable `gnark:",secret"`
+}
+
+// Define declares the circuit constraints.
+func (circuit *DLEQCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+	return AssertDLEQ(api, curve, circuit.G1, circuit.H1, circuit.G2, circuit.H2, circuit.X, circuit.R)
+}
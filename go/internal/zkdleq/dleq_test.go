@@ -0,0 +1,106 @@
+package zkdleq
+
+import (
+	cryptorand "crypto/rand"
+	"testing"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+func TestDLEQ(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// G1 is the curve's base point; G2 is a second generator obtained by
+	// doubling it (G2 = 2*G1), so H1 = x*G1 and H2 = x*G2 are proven
+	// equal in exponent without being the same point.
+	g1 := params.Base
+	var g2 tedwards.PointAffine
+	g2.Double(&g1)
+
+	var h1, h2 tedwards.PointAffine
+	h1.ScalarMultiplication(&g1, x)
+	h2.ScalarMultiplication(&g2, x)
+
+	circuit := DLEQCircuit{curveID: curve}
+	witnessAssignment := DLEQCircuit{
+		curveID: curve,
+		G1:      twistededwards.Point{X: g1.X, Y: g1.Y},
+		H1:      twistededwards.Point{X: h1.X, Y: h1.Y},
+		G2:      twistededwards.Point{X: g2.X, Y: g2.Y},
+		H2:      twistededwards.Point{X: h2.X, Y: h2.Y},
+		X:       x,
+		R:       r,
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBadDLEQ(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherX, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g1 := params.Base
+	var g2 tedwards.PointAffine
+	g2.Double(&g1)
+
+	var h1, h2 tedwards.PointAffine
+	h1.ScalarMultiplication(&g1, x)
+	// h2 uses a different exponent, so the DLEQ relation does not hold.
+	h2.ScalarMultiplication(&g2, otherX)
+
+	circuit := DLEQCircuit{curveID: curve}
+	witnessAssignment := DLEQCircuit{
+		curveID: curve,
+		G1:      twistededwards.Point{X: g1.X, Y: g1.Y},
+		H1:      twistededwards.Point{X: h1.X, Y: h1.Y},
+		G2:      twistededwards.Point{X: g2.X, Y: g2.Y},
+		H2:      twistededwards.Point{X: h2.X, Y: h2.Y},
+		X:       x,
+		R:       r,
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err == nil {
+		t.Fatal("Test failed: proof was successful while it should not be")
+	}
+}
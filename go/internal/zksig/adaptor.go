@@ -0,0 +1,64 @@
+package zksig
+
+import (
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+)
+
+// AdaptorEdDSACircuit proves that an EdDSA "pre-signature" (PreR, PreS)
+// completes into a valid EdDSA signature on Message under PublicKey once
+// the secret adaptor scalar T is added to PreS, and that T is indeed the
+// discrete log of the public adaptor point AdaptorPoint = T*G.
+//
+// This is one leg of a scriptless-script atomic swap: whoever learns t
+// from this pre-signature can complete any other pre-signature built
+// from the same scalar. Like SchnorrAdaptorCircuit, it does not itself
+// prove that a *paired* proof on another chain (e.g. over secp256k1) was
+// built from the same t - see SchnorrAdaptorCircuit's doc for why a hash
+// commitment can't bridge two differently-sized fields, and what a real
+// cross-chain binding would require.
+type AdaptorEdDSACircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	PublicKey stdeddsa.PublicKey `gnark:",secret"`
+	Message   frontend.Variable  `gnark:",public"`
+
+	// PreR/PreS is the EdDSA pre-signature (R', s'); AdaptorPoint is the
+	// public tweak point T = t*G. T itself (the scalar t) is secret.
+	PreR         twistededwards.Point `gnark:",secret"`
+	PreS         frontend.Variable    `gnark:",secret"`
+	AdaptorPoint twistededwards.Point `gnark:",public"`
+	T            frontend.Variable    `gnark:",secret"`
+}
+
+// Define declares the circuit constraints: assert AdaptorPoint == T*G,
+// complete the signature as R = PreR + AdaptorPoint and s = PreS + T, and
+// run the standard EdDSA verification equation on (R, s).
+func (circuit *AdaptorEdDSACircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+
+	params, err := tedwards.GetCurveParams(circuit.curveID)
+	if err != nil {
+		return err
+	}
+	base := twistededwards.Point{X: params.Base.X, Y: params.Base.Y}
+	tG := curve.ScalarMul(base, circuit.T)
+	api.AssertIsEqual(tG.X, circuit.AdaptorPoint.X)
+	api.AssertIsEqual(tG.Y, circuit.AdaptorPoint.Y)
+
+	r := curve.Add(circuit.PreR, circuit.AdaptorPoint)
+	s := api.Add(circuit.PreS, circuit.T)
+
+	sigHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	signature := stdeddsa.Signature{R: r, S: s}
+	return stdeddsa.Verify(curve, signature, circuit.Message, circuit.PublicKey, &sigHash)
+}
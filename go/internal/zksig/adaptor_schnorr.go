@@ -0,0 +1,184 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"log"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/emulated"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+)
+
+// SchnorrAdaptorCircuit proves that a Schnorr pre-signature (PreR, PreS)
+// completes into a valid Schnorr signature
+// s*G == (PreR + AdaptorPoint) + e*PublicKey on Challenge once the
+// secret adaptor scalar T is added to PreS, and that T is the discrete
+// log of the public adaptor point AdaptorPoint = T*G. That is the
+// scriptless-script primitive one leg of a cross-chain atomic swap
+// needs: whoever learns T by completing this pre-signature can complete
+// any other pre-signature built from the same scalar.
+//
+// This circuit does NOT prove that the same scalar t was used in a
+// paired proof on another chain (e.g. an AdaptorEdDSACircuit instance
+// over the BN254 twisted Edwards curve). AdaptorSecretCommitment -
+// MiMC(T.Limbs...) - only binds this circuit's own witness to a public
+// value; it is not comparable to anything AdaptorEdDSACircuit produces,
+// since T there is a single native field element while this circuit's T
+// is emulated.Element[S]'s limb decomposition, and the two circuits'
+// scalars live in different, differently-sized fields (the secp256k1
+// scalar field here vs. the twisted Edwards subgroup order there) that
+// a hash comparison cannot bridge. A real cross-chain binding needs a
+// genuine cross-curve DLEQ (typically a bit-decomposition range proof
+// tying one field's representation of t to the other's), which is out
+// of scope here; as shipped, each adaptor circuit only proves its own
+// single-curve pre-signature completion.
+type SchnorrAdaptorCircuit[T, S emulated.FieldParams] struct {
+	curveID ecc.ID `gnark:",secret"`
+
+	PublicKey stdecdsa.PublicKey[T, S] `gnark:",secret"`
+	// Challenge is the Schnorr challenge e already reduced mod the scalar
+	// field, taken as a public input rather than recomputed in-circuit
+	// from a message (as SchnorrCircuit does), since the challenge for
+	// the *completed* signature must be fixed before T is known.
+	Challenge emulated.Element[S] `gnark:",public"`
+
+	// PreR/PreS is the Schnorr pre-signature (R', s'); AdaptorPoint is
+	// the public tweak point T = t*G. T itself (the scalar t) is secret.
+	PreR         stdecdsa.PublicKey[T, S] `gnark:",secret"`
+	PreS         emulated.Element[S]      `gnark:",secret"`
+	AdaptorPoint stdecdsa.PublicKey[T, S] `gnark:",public"`
+	T            emulated.Element[S]      `gnark:",secret"`
+
+	// AdaptorSecretCommitment is MiMC(T.Limbs...), see the type doc.
+	AdaptorSecretCommitment frontend.Variable `gnark:",public"`
+}
+
+// Define declares the circuit constraints: assert AdaptorPoint == T*G,
+// complete the signature as R = PreR + AdaptorPoint and s = PreS + T, run
+// the Schnorr verification equation s*G == R + Challenge*PublicKey, and
+// assert AdaptorSecretCommitment == MiMC(T.Limbs...).
+func (circuit *SchnorrAdaptorCircuit[T, S]) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[T, S](api, sw_emulated.GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	scalarField, err := emulated.NewField[S](api)
+	if err != nil {
+		return err
+	}
+
+	tG := curve.ScalarMulBase(&circuit.T)
+	curve.AssertIsEqual(tG, &sw_emulated.AffinePoint[T]{X: circuit.AdaptorPoint.X, Y: circuit.AdaptorPoint.Y})
+
+	r := curve.AddUnified(
+		&sw_emulated.AffinePoint[T]{X: circuit.PreR.X, Y: circuit.PreR.Y},
+		&sw_emulated.AffinePoint[T]{X: circuit.AdaptorPoint.X, Y: circuit.AdaptorPoint.Y},
+	)
+	s := scalarField.Add(&circuit.PreS, &circuit.T)
+
+	lhs := curve.ScalarMulBase(s)
+	rhs := curve.AddUnified(r, curve.ScalarMul(&sw_emulated.AffinePoint[T]{X: circuit.PublicKey.X, Y: circuit.PublicKey.Y}, &circuit.Challenge))
+	curve.AssertIsEqual(lhs, rhs)
+
+	commitHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	commitHash.Write(circuit.T.Limbs...)
+	api.AssertIsEqual(commitHash.Sum(), circuit.AdaptorSecretCommitment)
+
+	return nil
+}
+
+// RunSchnorrAdaptor builds and proves a SchnorrAdaptorCircuit witness: a
+// pre-signature, a secret adaptor scalar t, and the resulting adaptor
+// point T = t*G, such that revealing t completes the pre-signature into a
+// valid Schnorr signature.
+func RunSchnorrAdaptor() {
+	const curve = ecc.BN254
+	snarkField := curve.ScalarField()
+	order := fr.Modulus()
+
+	_, _, g1Gen, _ := secp256k1.Generators()
+
+	priv, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	nonce, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	t, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var pub, preR, adaptorPoint secp256k1.G1Affine
+	pub.ScalarMultiplication(&g1Gen, priv)
+	preR.ScalarMultiplication(&g1Gen, nonce)
+	adaptorPoint.ScalarMultiplication(&g1Gen, t)
+
+	// The challenge is taken over the completed R = PreR + AdaptorPoint,
+	// matching the equation Define checks.
+	msgData := []byte("this is a cross-chain swap test message")
+	digest := sha256.Sum256(msgData)
+	var completedR secp256k1.G1Affine
+	completedR.Add(&preR, &adaptorPoint)
+	e := challengeScalar(completedR, pub, digest[:])
+
+	// preS = nonce + e*priv (mod order), so preS + t completes the
+	// signature: (nonce + t) + e*priv == s for R = (nonce+t)*G.
+	preS := new(big.Int).Mul(e, priv)
+	preS.Add(preS, nonce)
+	preS.Mod(preS, order)
+
+	circuit := SchnorrAdaptorCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{curveID: curve}
+	r1csSystem, err := frontend.Compile(snarkField, r1cs.NewBuilder, &circuit)
+	if err != nil {
+		log.Fatal("Failed to compile circuit! ", err)
+	}
+
+	pk, vk, err := groth16.Setup(r1csSystem)
+	if err != nil {
+		log.Fatal("Failed to perform setup! ", err)
+	}
+
+	tCommitment := mimcHashLimbs(limbsOf[emulated.Secp256k1Fr](t)...)
+
+	assignment := SchnorrAdaptorCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		PublicKey:               assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](pub),
+		Challenge:               emulated.ValueOf[emulated.Secp256k1Fr](e),
+		PreR:                    assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](preR),
+		PreS:                    emulated.ValueOf[emulated.Secp256k1Fr](preS),
+		AdaptorPoint:            assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](adaptorPoint),
+		T:                       emulated.ValueOf[emulated.Secp256k1Fr](t),
+		AdaptorSecretCommitment: tCommitment,
+	}
+
+	witness, err := frontend.NewWitness(&assignment, snarkField)
+	if err != nil {
+		log.Fatal("Failed to generate witness! ", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		log.Fatal("Failed to obtain public witness! ", err)
+	}
+
+	proof, err := groth16.Prove(r1csSystem, pk, witness)
+	if err != nil {
+		log.Fatal("Failed to generate proof! ", err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		log.Fatal("Failed to verify proof! ", err)
+	}
+}
@@ -0,0 +1,130 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+func TestSchnorrAdaptor(t *testing.T) {
+	const curve = ecc.BN254
+	snarkField := curve.ScalarField()
+	order := fr.Modulus()
+	_, _, g1Gen, _ := secp256k1.Generators()
+
+	priv, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adaptorSecret, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pub, preR, adaptorPoint secp256k1.G1Affine
+	pub.ScalarMultiplication(&g1Gen, priv)
+	preR.ScalarMultiplication(&g1Gen, nonce)
+	adaptorPoint.ScalarMultiplication(&g1Gen, adaptorSecret)
+
+	msgData := []byte("this is a cross-chain swap test message")
+	digest := sha256.Sum256(msgData)
+	var completedR secp256k1.G1Affine
+	completedR.Add(&preR, &adaptorPoint)
+	e := challengeScalar(completedR, pub, digest[:])
+
+	preS := new(big.Int).Mul(e, priv)
+	preS.Add(preS, nonce)
+	preS.Mod(preS, order)
+
+	// Sanity check: revealing adaptorSecret completes a valid signature.
+	completedS := new(big.Int).Add(preS, adaptorSecret)
+	completedS.Mod(completedS, order)
+	if !verifySchnorr(pub, completedR, completedS, msgData) {
+		t.Fatal("pre-signature does not complete into a valid signature")
+	}
+
+	circuit := SchnorrAdaptorCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{curveID: curve}
+
+	tCommitment := mimcHashLimbs(limbsOf[emulated.Secp256k1Fr](adaptorSecret)...)
+
+	witnessAssignment := SchnorrAdaptorCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		PublicKey:               assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](pub),
+		Challenge:               emulated.ValueOf[emulated.Secp256k1Fr](e),
+		PreR:                    assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](preR),
+		PreS:                    emulated.ValueOf[emulated.Secp256k1Fr](preS),
+		AdaptorPoint:            assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](adaptorPoint),
+		T:                       emulated.ValueOf[emulated.Secp256k1Fr](adaptorSecret),
+		AdaptorSecretCommitment: tCommitment,
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSchnorrAdaptorWrongCommitment checks that AdaptorSecretCommitment is
+// actually bound to T, not just carried along unchecked: a witness with a
+// tampered commitment must fail.
+func TestSchnorrAdaptorWrongCommitment(t *testing.T) {
+	const curve = ecc.BN254
+	snarkField := curve.ScalarField()
+	order := fr.Modulus()
+	_, _, g1Gen, _ := secp256k1.Generators()
+
+	priv, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adaptorSecret, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pub, preR, adaptorPoint secp256k1.G1Affine
+	pub.ScalarMultiplication(&g1Gen, priv)
+	preR.ScalarMultiplication(&g1Gen, nonce)
+	adaptorPoint.ScalarMultiplication(&g1Gen, adaptorSecret)
+
+	msgData := []byte("this is a cross-chain swap test message")
+	digest := sha256.Sum256(msgData)
+	var completedR secp256k1.G1Affine
+	completedR.Add(&preR, &adaptorPoint)
+	e := challengeScalar(completedR, pub, digest[:])
+
+	preS := new(big.Int).Mul(e, priv)
+	preS.Add(preS, nonce)
+	preS.Mod(preS, order)
+
+	circuit := SchnorrAdaptorCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{curveID: curve}
+
+	tamperedCommitment := new(big.Int).Add(mimcHashLimbs(limbsOf[emulated.Secp256k1Fr](adaptorSecret)...), big.NewInt(1))
+
+	witnessAssignment := SchnorrAdaptorCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		PublicKey:               assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](pub),
+		Challenge:               emulated.ValueOf[emulated.Secp256k1Fr](e),
+		PreR:                    assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](preR),
+		PreS:                    emulated.ValueOf[emulated.Secp256k1Fr](preS),
+		AdaptorPoint:            assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](adaptorPoint),
+		T:                       emulated.ValueOf[emulated.Secp256k1Fr](adaptorSecret),
+		AdaptorSecretCommitment: tamperedCommitment,
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err == nil {
+		t.Fatal("Test failed: proof was successful against a tampered AdaptorSecretCommitment")
+	}
+}
@@ -0,0 +1,99 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+// TestAdaptorEdDSA builds a real EdDSA signature, then splits it into a
+// pre-signature (R', s') and adaptor scalar t such that R = R' + t*G and
+// s = s' + t, and checks the circuit accepts the completed signature.
+func TestAdaptorEdDSA(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+	mathRandomness := rand.New(rand.NewSource(time.Now().Unix()))
+
+	key, err := eddsa.New(curve, cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedPublicKey := key.Public()
+
+	var msg big.Int
+	msg.Rand(mathRandomness, snarkField)
+	msgDataUnpadded := msg.Bytes()
+	msgData := make([]byte, len(snarkField.Bytes()))
+	copy(msgData[len(msgData)-len(msgDataUnpadded):], msgDataUnpadded)
+
+	signatureBytes, err := key.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := usedPublicKey.Verify(signatureBytes, msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValid {
+		t.Fatal("Invalid signature!")
+	}
+
+	sizeFr := len(snarkField.Bytes())
+	var fullR tedwards.PointAffine
+	fullR.X.SetBytes(signatureBytes[0:sizeFr])
+	fullR.Y.SetBytes(signatureBytes[sizeFr : 2*sizeFr])
+	fullS := new(big.Int).SetBytes(signatureBytes[2*sizeFr : 3*sizeFr])
+
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Split the signature: pick a random adaptor scalar t, derive the
+	// public tweak point T = t*G, and recover the pre-signature
+	// (R' = R - T, s' = s - t) that the circuit will complete.
+	adaptorScalar, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var adaptorPoint tedwards.PointAffine
+	adaptorPoint.ScalarMultiplication(&params.Base, adaptorScalar)
+
+	var negAdaptorPoint, preR tedwards.PointAffine
+	negAdaptorPoint.Neg(&adaptorPoint)
+	preR.Add(&fullR, &negAdaptorPoint)
+
+	preS := new(big.Int).Sub(fullS, adaptorScalar)
+	preS.Mod(preS, params.Order)
+
+	circuit := AdaptorEdDSACircuit{curveID: curve}
+	witnessAssignment := AdaptorEdDSACircuit{
+		curveID: curve,
+		Message: msg,
+		PreR:    twistededwards.Point{X: preR.X, Y: preR.Y},
+		PreS:    preS,
+		AdaptorPoint: twistededwards.Point{
+			X: adaptorPoint.X,
+			Y: adaptorPoint.Y,
+		},
+		T: adaptorScalar,
+	}
+	witnessAssignment.PublicKey.Assign(curve, usedPublicKey.Bytes())
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
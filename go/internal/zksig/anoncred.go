@@ -0,0 +1,116 @@
+package zksig
+
+import (
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+)
+
+// AnonCredCircuit proves possession of an EdDSA-signed credential while
+// selectively disclosing only some of its attributes, comparable to
+// Idemix/BBS+ selective disclosure but built natively on the MiMC/EdDSA
+// stack already used by EdDSACircuit.
+//
+// The credential is an ordered attribute vector Attributes, signed by the
+// issuer over MiMC(Attributes...). For each attribute i, DisclosureMask[i]
+// is 1 if that attribute is revealed (in which case it must equal
+// DisclosedValues[i]) and 0 if it stays hidden.
+type AnonCredCircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	// Attributes is the full credential, known only to the holder.
+	Attributes []frontend.Variable `gnark:",secret"`
+	Signature  stdeddsa.Signature  `gnark:",secret"`
+	PublicKey  stdeddsa.PublicKey  `gnark:",secret"`
+
+	// IssuerKeyList lets the holder prove the credential was signed by
+	// one of several recognized issuers, reusing the NAND-style
+	// membership loop from EdDSACircuit.
+	IssuerKeyList []stdeddsa.PublicKey `gnark:",public"`
+
+	// DisclosureMask/DisclosedValues are parallel to Attributes: a 1 in
+	// the mask reveals the corresponding attribute, which must then
+	// equal the public DisclosedValues entry. A 0 leaves the attribute
+	// free (any value the holder committed to is accepted).
+	DisclosureMask  []frontend.Variable `gnark:",public"`
+	DisclosedValues []frontend.Variable `gnark:",public"`
+}
+
+// Define declares the circuit constraints: recompute the credential
+// digest, verify the issuer's EdDSA signature over it and its membership
+// in IssuerKeyList, then enforce the disclosure mask attribute by
+// attribute.
+func (circuit *AnonCredCircuit) Define(api frontend.API) error {
+	if len(circuit.Attributes) != len(circuit.DisclosureMask) || len(circuit.Attributes) != len(circuit.DisclosedValues) {
+		return errAttributeLengthMismatch
+	}
+
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+
+	digestHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	digestHash.Write(circuit.Attributes...)
+	message := digestHash.Sum()
+
+	sigHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	if err := stdeddsa.Verify(curve, circuit.Signature, message, circuit.PublicKey, &sigHash); err != nil {
+		return err
+	}
+
+	// start with isUnknownIssuer at 1, meaning that the issuer is unknown
+	var isUnknownIssuer frontend.Variable = 1
+	for _, key := range circuit.IssuerKeyList {
+		equalX := api.IsZero(api.Cmp(circuit.PublicKey.A.X, key.A.X))
+		api.AssertIsBoolean(equalX)
+		equalY := api.IsZero(api.Cmp(circuit.PublicKey.A.Y, key.A.Y))
+		api.AssertIsBoolean(equalY)
+		differentKey := api.IsZero(api.And(equalX, equalY))
+		api.AssertIsBoolean(differentKey)
+		isUnknownIssuer = api.Mul(isUnknownIssuer, differentKey)
+		api.AssertIsBoolean(isUnknownIssuer)
+	}
+	api.AssertIsEqual(isUnknownIssuer, 0)
+
+	for i := range circuit.Attributes {
+		api.AssertIsBoolean(circuit.DisclosureMask[i])
+		// masked positions must equal the disclosed value; unmasked
+		// positions are unconstrained (mask_i == 0 zeroes the product).
+		diff := api.Sub(circuit.Attributes[i], circuit.DisclosedValues[i])
+		api.AssertIsEqual(api.Mul(circuit.DisclosureMask[i], diff), 0)
+	}
+
+	return nil
+}
+
+var errAttributeLengthMismatch = attributeLengthMismatchError{}
+
+type attributeLengthMismatchError struct{}
+
+func (attributeLengthMismatchError) Error() string {
+	return "zksig: Attributes, DisclosureMask and DisclosedValues must have the same length"
+}
+
+// PredicateGadget asserts value >= threshold over bitSize bits, letting a
+// credential prove a range predicate (e.g. "age >= 18") without revealing
+// value itself. Both value and threshold are assumed to fit in bitSize
+// bits; the caller picks bitSize to comfortably bound the attribute (e.g.
+// 8 bits for an age in years).
+func PredicateGadget(api frontend.API, value, threshold frontend.Variable, bitSize int) {
+	// value >= threshold  <=>  value - threshold does not borrow, i.e.
+	// value - threshold + 2^bitSize still fits in bitSize+1 bits with its
+	// top bit (bit bitSize) set. api.ToBinary here both range-checks the
+	// shifted difference and extracts that top bit.
+	shifted := api.Add(api.Sub(value, threshold), 1<<uint(bitSize))
+	bits := api.ToBinary(shifted, bitSize+1)
+	api.AssertIsEqual(bits[bitSize], 1)
+}
@@ -0,0 +1,135 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"testing"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/consensys/gnark/test"
+)
+
+// anonCredDigest mirrors, off-circuit, what Define computes in-circuit:
+// MiMC(Attributes...).
+func anonCredDigest(attributes []*big.Int) *big.Int {
+	h := hash.MIMC_BN254.New()
+	buf := make([]byte, 32)
+	for _, a := range attributes {
+		a.FillBytes(buf)
+		h.Write(buf)
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+func TestAnonCred(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+
+	issuer, err := eddsa.New(curve, cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherIssuer, err := eddsa.New(curve, cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerKeyList := []signature.PublicKey{otherIssuer.Public(), issuer.Public()}
+
+	// A three-attribute credential: name, age, country. Only age (index
+	// 1) is disclosed.
+	attributes := []*big.Int{big.NewInt(42), big.NewInt(21), big.NewInt(31)}
+	digest := anonCredDigest(attributes)
+
+	digestUnpadded := digest.Bytes()
+	msgData := make([]byte, len(snarkField.Bytes()))
+	copy(msgData[len(msgData)-len(digestUnpadded):], digestUnpadded)
+
+	sig, err := issuer.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := issuer.Public().Verify(sig, msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValid {
+		t.Fatal("Invalid signature!")
+	}
+
+	disclosureMask := []frontend.Variable{0, 1, 0}
+	disclosedValues := []frontend.Variable{0, 21, 0}
+
+	circuit := AnonCredCircuit{
+		curveID:         curve,
+		Attributes:      make([]frontend.Variable, len(attributes)),
+		IssuerKeyList:   make([]stdeddsa.PublicKey, len(issuerKeyList)),
+		DisclosureMask:  make([]frontend.Variable, len(attributes)),
+		DisclosedValues: make([]frontend.Variable, len(attributes)),
+	}
+
+	witnessAssignment := AnonCredCircuit{
+		Attributes:      make([]frontend.Variable, len(attributes)),
+		IssuerKeyList:   make([]stdeddsa.PublicKey, len(issuerKeyList)),
+		DisclosureMask:  disclosureMask,
+		DisclosedValues: disclosedValues,
+	}
+	for i, a := range attributes {
+		witnessAssignment.Attributes[i] = a
+	}
+	witnessAssignment.PublicKey.Assign(curve, issuer.Public().Bytes())
+	witnessAssignment.Signature.Assign(curve, sig)
+	for i, pk := range issuerKeyList {
+		var assignedKey stdeddsa.PublicKey
+		assignedKey.Assign(curve, pk.Bytes())
+		witnessAssignment.IssuerKeyList[i] = assignedKey
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnonCredPredicateGadget(t *testing.T) {
+	circuit := predicateCircuit{}
+	witnessAssignment := predicateCircuit{Value: 21, Threshold: 18}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, tedwardsSnarkField(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	badAssignment := predicateCircuit{Value: 16, Threshold: 18}
+	if err := test.IsSolved(&circuit, &badAssignment, tedwardsSnarkField(t)); err == nil {
+		t.Fatal("Test failed: predicate held for Value < Threshold")
+	}
+}
+
+// predicateCircuit exercises PredicateGadget in isolation.
+type predicateCircuit struct {
+	Value     frontend.Variable `gnark:",secret"`
+	Threshold frontend.Variable `gnark:",public"`
+}
+
+func (c *predicateCircuit) Define(api frontend.API) error {
+	PredicateGadget(api, c.Value, c.Threshold, 8)
+	return nil
+}
+
+func tedwardsSnarkField(t *testing.T) *big.Int {
+	t.Helper()
+	snarkField, err := twistededwards.GetSnarkField(tedwards.BN254)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snarkField
+}
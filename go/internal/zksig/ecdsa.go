@@ -2,21 +2,42 @@ package zksig
 
 import (
 	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
 	"log"
 	"math/big"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	secp256k1ecda "github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
-	"github.com/consensys/gnark/backend/groth16"
+	gnarkhash "github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/sha2"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
 	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+
+	zkbackend "nizkp_benchmark/internal/backend"
+	"nizkp_benchmark/internal/bench"
 )
 
 type ECDSAPublicKey stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]
 
+// ECDSAHashAlg selects which hash function ECDSACircuit folds Message
+// through before reducing it mod the signature's scalar field.
+// ECDSAHashSHA256 matches standards-compliant ECDSA; ECDSAHashMiMC is a
+// SNARK-friendly alternative for settings where both signer and verifier
+// agree to hash with MiMC instead, trading SHA-256's much larger circuit
+// footprint for a non-standard signature scheme.
+type ECDSAHashAlg int
+
+const (
+	ECDSAHashSHA256 ECDSAHashAlg = iota
+	ECDSAHashMiMC
+)
+
 // ECDSACircuit defines the ECDSA circuit
 type ECDSACircuit[T, S emulated.FieldParams] struct {
 	// struct tags on a variable is optional
@@ -24,18 +45,17 @@ type ECDSACircuit[T, S emulated.FieldParams] struct {
 	curveID   ecc.ID                     `gnark:",secret"`
 	PublicKey stdecdsa.PublicKey[T, S]   `gnark:",secret"`
 	Signature stdecdsa.Signature[S]      `gnark:",public"`
-	Message   emulated.Element[S]        `gnark:",public"`
+	Message   []uints.U8                 `gnark:",public"`
 	KeyList   []stdecdsa.PublicKey[T, S] `gnark:",public"`
+
+	// hashAlg picks Message's in-circuit hash function; it is a
+	// circuit-shape choice fixed at compile time (like curveID), not a
+	// witness value, so it is unexported and carries no gnark tag.
+	hashAlg ECDSAHashAlg
 }
 
 // Define declares the circuit constraints
 func (circuit *ECDSACircuit[T, S]) Define(api frontend.API) error {
-	// TODO: use hash
-	// mimc, err := mimc.NewMiMC(api)
-	// if err != nil {
-	// 	return err
-	// }
-
 	emulatedField, err := emulated.NewField[T](api)
 	if err != nil {
 		return err
@@ -63,11 +83,64 @@ func (circuit *ECDSACircuit[T, S]) Define(api frontend.API) error {
 	// if isUnknownKey is 1 then the used PublicKey is not in the KeyList, so the total proof must fail.
 	api.AssertIsEqual(isUnknownKey, 0)
 
-	// verify the signature in the constraint system
-	circuit.PublicKey.Verify(api, sw_emulated.GetCurveParams[T](), &circuit.Message, &circuit.Signature)
-	return nil //TODO: return error if fails, currently panics
+	scalarField, err := emulated.NewField[S](api)
+	if err != nil {
+		return err
+	}
+
+	// Hash Message in-circuit and reduce the digest mod the signature's
+	// scalar field, mirroring the off-circuit HashToInt this circuit used
+	// to require callers to precompute, so the prover only ever supplies
+	// the plaintext message. hashAlg selects between standards-compliant
+	// SHA-256 and the SNARK-friendly MiMC alternative (see ECDSAHashAlg);
+	// in both cases the digest is reduced via its bits rather than folded
+	// into one native accumulator first, since that accumulation happens
+	// mod the native SNARK field, which silently reduces a wide digest
+	// past that field's ~254-bit modulus before it ever reaches S,
+	// diverging from the off-circuit HashToInt the signature was made
+	// over.
+	var hashedMessage *emulated.Element[S]
+	switch circuit.hashAlg {
+	case ECDSAHashMiMC:
+		mimcHasher, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		nativeMessage := make([]frontend.Variable, len(circuit.Message))
+		for i, b := range circuit.Message {
+			nativeMessage[i] = b.Val
+		}
+		mimcHasher.Write(nativeMessage...)
+		digest := mimcHasher.Sum()
+
+		hashedMessage = scalarField.FromBits(api.ToBinary(digest)...)
+	default:
+		hasher, err := sha2.New(api)
+		if err != nil {
+			return err
+		}
+		hasher.Write(circuit.Message)
+		digest := hasher.Sum()
+
+		bits := make([]frontend.Variable, 0, len(digest)*8)
+		for i := len(digest) - 1; i >= 0; i-- {
+			bits = append(bits, api.ToBinary(digest[i].Val, 8)...)
+		}
+		hashedMessage = scalarField.FromBits(bits...)
+	}
+
+	// verify the signature in the constraint system; Verify asserts the
+	// ECDSA equation directly via in-circuit constraints rather than
+	// returning a Go error, so an invalid signature fails proving instead
+	// of surfacing here - there is nothing left for Define to propagate.
+	circuit.PublicKey.Verify(api, sw_emulated.GetCurveParams[T](), hashedMessage, &circuit.Signature)
+	return nil
 }
 
+// RunECDSA builds an ECDSACircuit witness once and reports metrics for it
+// under every zkbackend.Kind, so the benchmark harness can compare
+// Groth16 and PLONK instead of only ever exercising the hard-coded
+// Groth16 path this driver used to have.
 func RunECDSA() {
 	// Define the curve to use
 	const curve = ecc.BN254
@@ -92,13 +165,13 @@ func RunECDSA() {
 
 	// Sign a message
 	msgData := []byte("this is a test message")
-	signatureBytes, err := usedKey.Sign(msgData, nil) //TODO: use hash function, not nil
+	signatureBytes, err := usedKey.Sign(msgData, sha256.New())
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// Verify the signature correctness before generating the proof
-	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, nil) //TODO: use hash function, not nil
+	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, sha256.New())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -106,36 +179,105 @@ func RunECDSA() {
 		log.Fatal("Invalid signature!")
 	}
 
-	// Compile the circuit into R1CS
 	circuit := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
 		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
 		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
-	r1cs, err := frontend.Compile(snarkField, r1cs.NewBuilder, &circuit)
+
+	// Define the witness
+	assignment := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Signature: AssignSignature[emulated.Secp256k1Fr](signatureBytes),
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey),
+		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+	// assign the keylist by assigning each key in the list
+	for i, pk := range keyList {
+		assignment.KeyList[i] = pk
+	}
+
+	for _, kind := range []zkbackend.Kind{zkbackend.Groth16, zkbackend.Plonk} {
+		runECDSAWithBackend(kind, snarkField, &circuit, &assignment)
+	}
+}
+
+// RunECDSAMiMC mirrors RunECDSA but signs over a MiMC digest instead of
+// SHA-256 and sets hashAlg accordingly, demonstrating the SNARK-friendly
+// ECDSACircuit variant: both signer and in-circuit verifier must agree on
+// MiMC as the hash function, since ECDSA itself does not mandate SHA-256.
+func RunECDSAMiMC() {
+	const curve = ecc.BN254
+	snarkField := curve.ScalarField()
+
+	cryptoRandomness := cryptorand.Reader
+	usedKey, err := secp256k1ecda.GenerateKey(cryptoRandomness)
 	if err != nil {
-		log.Fatal("Failed to compile circuit! ", err)
+		log.Fatal(err)
 	}
+	usedPublicKey := AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey)
+	differentKey, err := secp256k1ecda.GenerateKey(cryptoRandomness)
+	if err != nil {
+		log.Fatal(err)
+	}
+	differentPublicKey := AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](differentKey.PublicKey)
+	keyList := []stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{differentPublicKey, usedPublicKey}
 
-	// Setup the groth16 zkSNARK proof
-	pk, vk, err := groth16.Setup(r1cs)
+	msgData := []byte("this is a test message")
+	signatureBytes, err := usedKey.Sign(msgData, gnarkhash.MIMC_BN254.New())
 	if err != nil {
-		log.Fatal("Failed to perform setup! ", err)
+		log.Fatal(err)
+	}
+
+	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, gnarkhash.MIMC_BN254.New())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !isValid {
+		log.Fatal("Invalid signature!")
+	}
+
+	circuit := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		hashAlg: ECDSAHashMiMC,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
 
-	// Define the witness
 	assignment := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
 		Signature: AssignSignature[emulated.Secp256k1Fr](signatureBytes),
-		Message:   emulated.ValueOf[emulated.Secp256k1Fr](secp256k1ecda.HashToInt(msgData)),
+		Message:   uints.NewU8Array(msgData),
 		PublicKey: AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey),
 		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
-
-	// assign the keylist by assigning each key in the list
 	for i, pk := range keyList {
 		assignment.KeyList[i] = pk
 	}
 
-	witness, err := frontend.NewWitness(&assignment, snarkField)
+	for _, kind := range []zkbackend.Kind{zkbackend.Groth16, zkbackend.Plonk} {
+		runECDSAWithBackend(kind, snarkField, &circuit, &assignment)
+	}
+}
+
+// runECDSAWithBackend compiles, sets up, proves and verifies circuit under
+// a single zkbackend.Kind, timing each phase and reporting the resulting
+// metrics labelled with that Kind.
+func runECDSAWithBackend(kind zkbackend.Kind, snarkField *big.Int, circuit, assignment *ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]) {
+	compileStart := time.Now()
+	ccs, err := zkbackend.Compile(kind, snarkField, circuit)
+	if err != nil {
+		log.Fatal("Failed to compile circuit! ", err)
+	}
+	compileDuration := time.Since(compileStart)
+
+	setupStart := time.Now()
+	pk, vk, err := zkbackend.Setup(kind, ccs)
+	if err != nil {
+		log.Fatal("Failed to perform setup! ", err)
+	}
+	setupDuration := time.Since(setupStart)
+
+	witness, err := frontend.NewWitness(assignment, snarkField)
 	if err != nil {
 		log.Fatal("Failed to generate witness! ", err)
 	}
@@ -144,14 +286,29 @@ func RunECDSA() {
 		log.Fatal("Failed to obtain public witness! ", err)
 	}
 
-	// Generate the Groth16 proof and verify it
-	proof, err := groth16.Prove(r1cs, pk, witness)
+	proveStart := time.Now()
+	proof, err := zkbackend.Prove(kind, ccs, pk, witness)
 	if err != nil {
 		log.Fatal("Failed to generate proof! ", err)
 	}
-	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+	proveDuration := time.Since(proveStart)
+
+	verifyStart := time.Now()
+	if err := zkbackend.Verify(kind, proof, vk, publicWitness); err != nil {
 		log.Fatal("Failed to verify proof! ", err)
 	}
+	verifyDuration := time.Since(verifyStart)
+
+	label := "ECDSA"
+	if circuit.hashAlg == ECDSAHashMiMC {
+		label = "ECDSA-MiMC"
+	}
+	bench.Report(fmt.Sprintf("%s/%s", label, kind), ccs, pk, vk, proof, bench.Timings{
+		Compile: compileDuration,
+		Setup:   setupDuration,
+		Prove:   proveDuration,
+		Verify:  verifyDuration,
+	})
 }
 
 func AssignSignature[S emulated.FieldParams](signatureBytes []byte) stdecdsa.Signature[S] {
@@ -0,0 +1,28 @@
+package zksig
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/consensys/gnark/std/math/emulated"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+)
+
+// AssignNISTKey converts a standard library crypto/ecdsa public key (P-256
+// or P-384) into the emulated representation ECDSACircuit expects, so
+// TLS/JWT-style X.509 keys can be proven over, not just secp256k1 keys.
+func AssignNISTKey[T, S emulated.FieldParams](publicKey *ecdsa.PublicKey) stdecdsa.PublicKey[T, S] {
+	return stdecdsa.PublicKey[T, S]{
+		X: emulated.ValueOf[T](publicKey.X),
+		Y: emulated.ValueOf[T](publicKey.Y),
+	}
+}
+
+// AssignNISTSignature converts a standard library (r, s) signature pair
+// into the emulated representation ECDSACircuit expects.
+func AssignNISTSignature[S emulated.FieldParams](r, s *big.Int) stdecdsa.Signature[S] {
+	return stdecdsa.Signature[S]{
+		R: emulated.ValueOf[S](r),
+		S: emulated.ValueOf[S](s),
+	}
+}
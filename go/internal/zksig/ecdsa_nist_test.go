@@ -0,0 +1,166 @@
+package zksig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+	"github.com/consensys/gnark/test"
+)
+
+// messageWithDigestAtLeast finds a message whose SHA-256 digest, read as
+// a big-endian integer, is at least floor. ECDSACircuit reduces the digest
+// into the emulated scalar field via its bits; a reduction that instead
+// folded the digest into one native SNARK-field accumulator (~2^254 for
+// BN254) would silently wrap such a digest but not a smaller one, so this
+// exercises the digest-overflow case on purpose, rather than leaving it
+// to whichever digest a fixed string happens to hash to.
+func messageWithDigestAtLeast(t *testing.T, prefix string, floor *big.Int) []byte {
+	t.Helper()
+	for i := 0; ; i++ {
+		msg := []byte(fmt.Sprintf("%s #%d", prefix, i))
+		digest := sha256.Sum256(msg)
+		if new(big.Int).SetBytes(digest[:]).Cmp(floor) >= 0 {
+			return msg
+		}
+	}
+}
+
+// TestECDSAP256 proves knowledge of a signer of a message under a NIST
+// P-256 key, the curve used by TLS/X.509 certificates and JWTs, as
+// opposed to the Bitcoin-style secp256k1 keys TestECDSA exercises.
+func TestECDSAP256(t *testing.T) {
+	// Define the curve to use
+	const curve = ecc.BN254
+	// Obtain the corresponding snarkField
+	snarkField := curve.ScalarField()
+
+	// Create a P-256 usedKey pair to use for signing
+	cryptoRandomness := cryptorand.Reader
+	usedKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedPublicKey := AssignNISTKey[emulated.P256Fp, emulated.P256Fr](&usedKey.PublicKey)
+	// Create a different P-256 public key
+	differentKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	differentPublicKey := AssignNISTKey[emulated.P256Fp, emulated.P256Fr](&differentKey.PublicKey)
+	// Add all public keys to the key list
+	keyList := []stdecdsa.PublicKey[emulated.P256Fp, emulated.P256Fr]{differentPublicKey, usedPublicKey}
+
+	// Sign the SHA-256 digest of a message, as TLS/JWT signers do; the
+	// circuit recomputes this same digest in-circuit from the plaintext
+	// message. Pick a message whose digest is at least the BN254 scalar
+	// field's modulus, so this test actually exercises the case where the
+	// digest would be silently truncated if it were folded mod the native
+	// SNARK field before being reduced into the emulated scalar field S.
+	msgData := messageWithDigestAtLeast(t, "this is a TLS-style test message", snarkField)
+	digest := sha256.Sum256(msgData)
+	r, s, err := ecdsa.Sign(cryptoRandomness, usedKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify the signature correctness before generating the proof
+	if !ecdsa.Verify(&usedKey.PublicKey, digest[:], r, s) {
+		t.Fatal("Invalid signature!")
+	}
+
+	// Define the circuit
+	circuit := ECDSACircuit[emulated.P256Fp, emulated.P256Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.P256Fp, emulated.P256Fr], len(keyList)),
+	}
+
+	// Define the witnessAssignment
+	witnessAssignment := ECDSACircuit[emulated.P256Fp, emulated.P256Fr]{
+		Signature: AssignNISTSignature[emulated.P256Fr](r, s),
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: usedPublicKey,
+		KeyList:   make([]stdecdsa.PublicKey[emulated.P256Fp, emulated.P256Fr], len(keyList)),
+	}
+	// assign the keylist by assigning each key in the list
+	for i, pk := range keyList {
+		witnessAssignment.KeyList[i] = pk
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestECDSAP384 proves knowledge of a signer of a message under a NIST
+// P-384 key. The circuit's in-circuit hash is SHA-256 regardless of the
+// key's curve, so the off-chain signer here also signs a SHA-256 digest
+// rather than the SHA-384 digest a real P-384/TLS deployment would use.
+func TestECDSAP384(t *testing.T) {
+	// Define the curve to use
+	const curve = ecc.BN254
+	// Obtain the corresponding snarkField
+	snarkField := curve.ScalarField()
+
+	// Create a P-384 usedKey pair to use for signing
+	cryptoRandomness := cryptorand.Reader
+	usedKey, err := ecdsa.GenerateKey(elliptic.P384(), cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedPublicKey := AssignNISTKey[emulated.P384Fp, emulated.P384Fr](&usedKey.PublicKey)
+	// Create a different P-384 public key
+	differentKey, err := ecdsa.GenerateKey(elliptic.P384(), cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	differentPublicKey := AssignNISTKey[emulated.P384Fp, emulated.P384Fr](&differentKey.PublicKey)
+	// Add all public keys to the key list
+	keyList := []stdecdsa.PublicKey[emulated.P384Fp, emulated.P384Fr]{differentPublicKey, usedPublicKey}
+
+	// Sign the SHA-256 digest of a message, again picked so the digest is
+	// at least the BN254 scalar field's modulus (see TestECDSAP256).
+	msgData := messageWithDigestAtLeast(t, "this is another TLS-style test message", snarkField)
+	digest := sha256.Sum256(msgData)
+	r, s, err := ecdsa.Sign(cryptoRandomness, usedKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify the signature correctness before generating the proof
+	if !ecdsa.Verify(&usedKey.PublicKey, digest[:], r, s) {
+		t.Fatal("Invalid signature!")
+	}
+
+	// Define the circuit
+	circuit := ECDSACircuit[emulated.P384Fp, emulated.P384Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.P384Fp, emulated.P384Fr], len(keyList)),
+	}
+
+	// Define the witnessAssignment
+	witnessAssignment := ECDSACircuit[emulated.P384Fp, emulated.P384Fr]{
+		Signature: AssignNISTSignature[emulated.P384Fr](r, s),
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: usedPublicKey,
+		KeyList:   make([]stdecdsa.PublicKey[emulated.P384Fp, emulated.P384Fr], len(keyList)),
+	}
+	// assign the keylist by assigning each key in the list
+	for i, pk := range keyList {
+		witnessAssignment.KeyList[i] = pk
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,12 +2,15 @@ package zksig
 
 import (
 	cryptorand "crypto/rand"
+	"crypto/sha256"
 	"log"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	secp256k1ecda "github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
+	gnarkhash "github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
 	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
 	"github.com/consensys/gnark/test"
 )
@@ -36,13 +39,13 @@ func TestECDSA(t *testing.T) {
 
 	// Sign a message
 	msgData := []byte("this is a test message")
-	signatureBytes, err := usedKey.Sign(msgData, nil) //TODO: use hash function, not nil
+	signatureBytes, err := usedKey.Sign(msgData, sha256.New())
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// Verify the signature correctness before generating the proof
-	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, nil) //TODO: use hash function, not nil
+	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, sha256.New())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -53,13 +56,14 @@ func TestECDSA(t *testing.T) {
 	// Define the circuit
 	circuit := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
 		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
 		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
 
 	// Define the witnessAssignment
 	witnessAssignment := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
 		Signature: AssignSignature[emulated.Secp256k1Fr](signatureBytes),
-		Message:   emulated.ValueOf[emulated.Secp256k1Fr](secp256k1ecda.HashToInt(msgData)),
+		Message:   uints.NewU8Array(msgData),
 		PublicKey: AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey),
 		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
@@ -96,13 +100,13 @@ func TestBadECDSA(t *testing.T) {
 
 	// Sign a message
 	msgData := []byte("this is a test message")
-	signatureBytes, err := usedKey.Sign(msgData, nil) //TODO: use hash function, not nil
+	signatureBytes, err := usedKey.Sign(msgData, sha256.New())
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// Verify the signature correctness before generating the proof
-	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, nil) //TODO: use hash function, not nil
+	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, sha256.New())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -113,13 +117,14 @@ func TestBadECDSA(t *testing.T) {
 	// Define the circuit
 	circuit := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
 		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
 		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
 
 	// Define the witnessAssignment
 	witnessAssignment := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
 		Signature: AssignSignature[emulated.Secp256k1Fr](signatureBytes),
-		Message:   emulated.ValueOf[emulated.Secp256k1Fr](secp256k1ecda.HashToInt(msgData)),
+		Message:   uints.NewU8Array(msgData),
 		PublicKey: AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey),
 		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
 	}
@@ -132,3 +137,59 @@ func TestBadECDSA(t *testing.T) {
 		t.Fatal("Test failed: proof was successful while it should not be")
 	}
 }
+
+// TestECDSAMiMC is TestECDSA's counterpart for the SNARK-friendly hashAlg
+// path: the signature is produced over a MiMC digest instead of SHA-256,
+// and the circuit is compiled with hashAlg: ECDSAHashMiMC to match.
+func TestECDSAMiMC(t *testing.T) {
+	const curve = ecc.BN254
+	snarkField := curve.ScalarField()
+
+	cryptoRandomness := cryptorand.Reader
+	usedKey, err := secp256k1ecda.GenerateKey(cryptoRandomness)
+	if err != nil {
+		log.Fatal(err)
+	}
+	usedPublicKey := AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey)
+	differentKey, err := secp256k1ecda.GenerateKey(cryptoRandomness)
+	if err != nil {
+		log.Fatal(err)
+	}
+	differentPublicKey := AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](differentKey.PublicKey)
+	keyList := []stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{differentPublicKey, usedPublicKey}
+
+	msgData := []byte("this is a test message")
+	signatureBytes, err := usedKey.Sign(msgData, gnarkhash.MIMC_BN254.New())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	isValid, err := usedKey.Public().Verify(signatureBytes, msgData, gnarkhash.MIMC_BN254.New())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !isValid {
+		log.Fatal("Invalid signature!")
+	}
+
+	circuit := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		hashAlg: ECDSAHashMiMC,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+
+	witnessAssignment := ECDSACircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Signature: AssignSignature[emulated.Secp256k1Fr](signatureBytes),
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: AssignKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedKey.PublicKey),
+		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+	for i, pk := range keyList {
+		witnessAssignment.KeyList[i] = pk
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
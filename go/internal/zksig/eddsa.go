@@ -17,6 +17,8 @@ import (
 	"github.com/consensys/gnark/std/algebra/native/twistededwards"
 	"github.com/consensys/gnark/std/hash/mimc"
 	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+
+	"nizkp_benchmark/internal/zkcommit"
 )
 
 // EdDSACircuit defines the EdDSA circuit
@@ -28,6 +30,19 @@ type EdDSACircuit struct {
 	Signature stdeddsa.Signature   `gnark:",public"`
 	Message   frontend.Variable    `gnark:",public"`
 	KeyList   []stdeddsa.PublicKey `gnark:",public"`
+
+	// Commitments/Openings/Blindings are optional: when Commitments is
+	// non-empty, Message must equal MiMC(Commitments[0].X,
+	// Commitments[0].Y, ..., Commitments[k].X, Commitments[k].Y) and
+	// each Commitments[i] must open to Openings[i] under Blindings[i],
+	// so the EdDSA signature binds to a hash of commitments rather than
+	// plaintext values - a signed-commitment primitive for
+	// confidential-transaction-style proofs.
+	Commitments     []twistededwards.Point `gnark:",public"`
+	CommitmentH     twistededwards.Point   `gnark:",public"`
+	CommitmentBases []twistededwards.Point `gnark:",public"`
+	Openings        [][]frontend.Variable  `gnark:",secret"`
+	Blindings       []frontend.Variable    `gnark:",secret"`
 }
 
 // Define declares the circuit constraints
@@ -37,7 +52,7 @@ func (circuit *EdDSACircuit) Define(api frontend.API) error {
 		return err
 	}
 
-	mimc, err := mimc.NewMiMC(api)
+	sigHash, err := mimc.NewMiMC(api)
 	if err != nil {
 		return err
 	}
@@ -64,8 +79,40 @@ func (circuit *EdDSACircuit) Define(api frontend.API) error {
 	// if isUnknownKey is 1 then the used PublicKey is not in the KeyList, so the total proof must fail.
 	api.AssertIsEqual(isUnknownKey, 0)
 
+	// Commitments is optional: when present, verify every opening and
+	// assert Message is the MiMC digest of the commitments, so the
+	// signature binds to the commitments rather than a plaintext value.
+	if len(circuit.Commitments) > 0 {
+		if len(circuit.Commitments) != len(circuit.Openings) || len(circuit.Commitments) != len(circuit.Blindings) {
+			return errCommitmentWitnessMismatch
+		}
+
+		for i, commitment := range circuit.Commitments {
+			if err := zkcommit.AssertOpening(api, curve, commitment, circuit.CommitmentH, circuit.CommitmentBases, circuit.Openings[i], circuit.Blindings[i]); err != nil {
+				return err
+			}
+		}
+
+		digestHash, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		for _, commitment := range circuit.Commitments {
+			digestHash.Write(commitment.X, commitment.Y)
+		}
+		api.AssertIsEqual(digestHash.Sum(), circuit.Message)
+	}
+
 	// verify the signature in the constraint system
-	return stdeddsa.Verify(curve, circuit.Signature, circuit.Message, circuit.PublicKey, &mimc)
+	return stdeddsa.Verify(curve, circuit.Signature, circuit.Message, circuit.PublicKey, &sigHash)
+}
+
+var errCommitmentWitnessMismatch = commitmentWitnessMismatchError{}
+
+type commitmentWitnessMismatchError struct{}
+
+func (commitmentWitnessMismatchError) Error() string {
+	return "zksig: Commitments, Openings and Blindings must have the same length"
 }
 
 func RunEdDSA() {
@@ -11,9 +11,12 @@ import (
 	"github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark-crypto/signature"
 	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/native/twistededwards"
 	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
 	"github.com/consensys/gnark/test"
+
+	"nizkp_benchmark/internal/zkcommit"
 )
 
 func TestEdDSA(t *testing.T) {
@@ -165,3 +168,78 @@ func TestBadEdDSA(t *testing.T) {
 		t.Fatal("Test failed: proof was successful while it should not be")
 	}
 }
+
+func TestEdDSAWithCommitments(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+
+	key, err := eddsa.New(curve, cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedPublicKey := key.Public()
+
+	// A single commitment to (amount, fee) under one blinding factor.
+	tags := []string{"eddsa-test/amount", "eddsa-test/fee"}
+	values := []*big.Int{big.NewInt(100), big.NewInt(5)}
+	blinding := big.NewInt(7)
+
+	h, bases, err := zkcommit.Setup(curve, tags...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitment, err := zkcommit.ComputeOffCircuit(curve, tags, values, blinding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := zkcommit.DigestOffCircuit(commitment)
+	digestUnpadded := digest.Bytes()
+	msgData := make([]byte, len(snarkField.Bytes()))
+	copy(msgData[len(msgData)-len(digestUnpadded):], digestUnpadded)
+
+	sig, err := key.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := usedPublicKey.Verify(sig, msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValid {
+		t.Fatal("Invalid signature!")
+	}
+
+	// EdDSACircuit always checks PublicKey against KeyList, so the
+	// signer's key is listed alongside the commitments.
+	circuit := EdDSACircuit{
+		curveID:         curve,
+		KeyList:         make([]stdeddsa.PublicKey, 1),
+		Commitments:     make([]twistededwards.Point, 1),
+		CommitmentBases: make([]twistededwards.Point, len(bases)),
+		Openings:        [][]frontend.Variable{make([]frontend.Variable, len(values))},
+		Blindings:       make([]frontend.Variable, 1),
+	}
+
+	witnessAssignment := EdDSACircuit{
+		Message:         *digest,
+		KeyList:         make([]stdeddsa.PublicKey, 1),
+		Commitments:     []twistededwards.Point{commitment},
+		CommitmentH:     h,
+		CommitmentBases: bases,
+		Openings:        [][]frontend.Variable{{values[0], values[1]}},
+		Blindings:       []frontend.Variable{blinding},
+	}
+	witnessAssignment.PublicKey.Assign(curve, usedPublicKey.Bytes())
+	witnessAssignment.Signature.Assign(curve, sig)
+	witnessAssignment.KeyList[0].Assign(curve, usedPublicKey.Bytes())
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
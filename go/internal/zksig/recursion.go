@@ -0,0 +1,60 @@
+package zksig
+
+import (
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/native/sw_bn254"
+	"github.com/consensys/gnark/frontend"
+	stdplonk "github.com/consensys/gnark/std/recursion/plonk"
+)
+
+// RecursiveEdDSACircuit verifies, inside an outer BW6-761 circuit, a PLONK
+// proof of an inner EdDSACircuit instance over BN254. This lets many
+// EdDSA signatures be aggregated under a single outer proof instead of
+// paying for N independent Groth16/PLONK verifications.
+//
+// The inner curve's pairing (sw_bn254) backs the KZG verifier used by the
+// recursion gadget; its Fiat-Shamir transcript is seeded from the inner
+// public witness digest, exactly as std/recursion/plonk expects.
+//
+// VerifyingKey is a public witness input, not a compile-time constant:
+// the circuit is pinned to whichever inner EdDSACircuit shape its
+// PlaceholderVerifyingKey was sized for, but the concrete KZG commitments
+// are only fixed at proving time, so a proof that is valid under one
+// inner verifying key is rejected if checked against a different one.
+type RecursiveEdDSACircuit struct {
+	Proof        stdplonk.Proof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine]
+	VerifyingKey stdplonk.VerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine] `gnark:",public"`
+	InnerWitness stdplonk.Witness[sw_bn254.ScalarField]
+}
+
+// Define declares the outer circuit: instantiate a PLONK verifier over
+// the inner curve's pairing and assert the inner proof is valid for
+// VerifyingKey/InnerWitness.
+func (circuit *RecursiveEdDSACircuit) Define(api frontend.API) error {
+	verifier, err := stdplonk.NewVerifier[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	return AssertProof(verifier, circuit.VerifyingKey, circuit.Proof, circuit.InnerWitness)
+}
+
+// recursiveVerifier is the subset of stdplonk.Verifier that AssertProof
+// needs, so it can be reused by any outer circuit that recursively
+// verifies an inner PLONK proof (EdDSA, ECDSA, or otherwise), not just
+// RecursiveEdDSACircuit.
+type recursiveVerifier[FR algebra.ScalarField, G1El algebra.G1ElementT, G2El algebra.G2ElementT] interface {
+	AssertProof(vk stdplonk.VerifyingKey[FR, G1El, G2El], proof stdplonk.Proof[FR, G1El, G2El], witness stdplonk.Witness[FR], opts ...stdplonk.Option) error
+}
+
+// AssertProof is a reusable gadget wrapping verifier.AssertProof, so
+// downstream circuits that aggregate multiple signature proofs (EdDSA,
+// ECDSA, ...) can call into the same recursive-verification glue without
+// re-deriving the KZG/Fiat-Shamir wiring.
+func AssertProof[FR algebra.ScalarField, G1El algebra.G1ElementT, G2El algebra.G2ElementT](
+	verifier recursiveVerifier[FR, G1El, G2El],
+	vk stdplonk.VerifyingKey[FR, G1El, G2El],
+	proof stdplonk.Proof[FR, G1El, G2El],
+	witness stdplonk.Witness[FR],
+) error {
+	return verifier.AssertProof(vk, proof, witness, stdplonk.WithCompleteArithmetic())
+}
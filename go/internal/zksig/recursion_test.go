@@ -0,0 +1,245 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/sw_bn254"
+	stdplonk "github.com/consensys/gnark/std/recursion/plonk"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/consensys/gnark/test"
+
+	"nizkp_benchmark/internal/backend"
+)
+
+// TestRecursiveEdDSA proves an inner EdDSACircuit instance under PLONK,
+// wraps the resulting proof as a RecursiveEdDSACircuit witness, and
+// checks the outer BW6-761 circuit accepts it - exercising AssertProof
+// against a genuine proof rather than leaving the recursion glue
+// completely untested.
+func TestRecursiveEdDSA(t *testing.T) {
+	const innerCurve = twistededwards.BN254
+	innerField := ecc.BN254.ScalarField()
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+	mathRandomness := rand.New(rand.NewSource(time.Now().Unix()))
+
+	key, err := eddsa.New(innerCurve, cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedPublicKey := key.Public()
+	keyList := []signature.PublicKey{usedPublicKey}
+
+	var msg big.Int
+	msg.Rand(mathRandomness, innerField)
+	msgDataUnpadded := msg.Bytes()
+	msgData := make([]byte, len(innerField.Bytes()))
+	copy(msgData[len(msgData)-len(msgDataUnpadded):], msgDataUnpadded)
+
+	sig, err := key.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := usedPublicKey.Verify(sig, msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValid {
+		t.Fatal("Invalid signature!")
+	}
+
+	innerCircuit := EdDSACircuit{
+		curveID: innerCurve,
+		KeyList: make([]stdeddsa.PublicKey, len(keyList)),
+	}
+	innerAssignment := EdDSACircuit{
+		Message: msg,
+		KeyList: make([]stdeddsa.PublicKey, len(keyList)),
+	}
+	innerAssignment.PublicKey.Assign(innerCurve, usedPublicKey.Bytes())
+	innerAssignment.Signature.Assign(innerCurve, sig)
+	for i, pk := range keyList {
+		var assignedKey stdeddsa.PublicKey
+		assignedKey.Assign(innerCurve, pk.Bytes())
+		innerAssignment.KeyList[i] = assignedKey
+	}
+
+	innerCcs, err := backend.Compile(backend.Plonk, innerField, &innerCircuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	innerPK, innerVK, err := backend.Setup(backend.Plonk, innerCcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	innerWitness, err := frontend.NewWitness(&innerAssignment, innerField)
+	if err != nil {
+		t.Fatal(err)
+	}
+	innerPublicWitness, err := innerWitness.Public()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	innerProof, err := backend.Prove(backend.Plonk, innerCcs, innerPK, innerWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Verify(backend.Plonk, innerProof, innerVK, innerPublicWitness); err != nil {
+		t.Fatal(err)
+	}
+
+	circuitVK, err := stdplonk.ValueOfVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerVK.(plonk.VerifyingKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	circuitWitness, err := stdplonk.ValueOfWitness[sw_bn254.ScalarField](innerPublicWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	circuitProof, err := stdplonk.ValueOfProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerProof.(plonk.Proof))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outerCircuit := &RecursiveEdDSACircuit{
+		Proof:        stdplonk.PlaceholderProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		VerifyingKey: stdplonk.PlaceholderVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		InnerWitness: stdplonk.PlaceholderWitness[sw_bn254.ScalarField](innerCcs),
+	}
+	outerAssignment := &RecursiveEdDSACircuit{
+		Proof:        circuitProof,
+		VerifyingKey: circuitVK,
+		InnerWitness: circuitWitness,
+	}
+
+	outerField := ecc.BW6_761.ScalarField()
+	if err := test.IsSolved(outerCircuit, outerAssignment, outerField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRecursiveEdDSAWrongVerifyingKey checks that VerifyingKey is
+// actually consumed by AssertProof rather than carried along unchecked:
+// a witness pairing a genuine proof with a different inner verifying key
+// (from an independent setup of the same circuit shape, hence a
+// different KZG SRS) must be rejected.
+func TestRecursiveEdDSAWrongVerifyingKey(t *testing.T) {
+	const innerCurve = twistededwards.BN254
+	innerField := ecc.BN254.ScalarField()
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+	mathRandomness := rand.New(rand.NewSource(time.Now().Unix()))
+
+	key, err := eddsa.New(innerCurve, cryptoRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usedPublicKey := key.Public()
+	keyList := []signature.PublicKey{usedPublicKey}
+
+	var msg big.Int
+	msg.Rand(mathRandomness, innerField)
+	msgDataUnpadded := msg.Bytes()
+	msgData := make([]byte, len(innerField.Bytes()))
+	copy(msgData[len(msgData)-len(msgDataUnpadded):], msgDataUnpadded)
+
+	sig, err := key.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := usedPublicKey.Verify(sig, msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValid {
+		t.Fatal("Invalid signature!")
+	}
+
+	innerCircuit := EdDSACircuit{
+		curveID: innerCurve,
+		KeyList: make([]stdeddsa.PublicKey, len(keyList)),
+	}
+	innerAssignment := EdDSACircuit{
+		Message: msg,
+		KeyList: make([]stdeddsa.PublicKey, len(keyList)),
+	}
+	innerAssignment.PublicKey.Assign(innerCurve, usedPublicKey.Bytes())
+	innerAssignment.Signature.Assign(innerCurve, sig)
+	for i, pk := range keyList {
+		var assignedKey stdeddsa.PublicKey
+		assignedKey.Assign(innerCurve, pk.Bytes())
+		innerAssignment.KeyList[i] = assignedKey
+	}
+
+	innerCcs, err := backend.Compile(backend.Plonk, innerField, &innerCircuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	innerPK, innerVK, err := backend.Setup(backend.Plonk, innerCcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An independent setup of the same circuit shape: a fresh KZG SRS, so
+	// its verifying key differs from innerVK even though both describe
+	// the same constraint system.
+	_, wrongVK, err := backend.Setup(backend.Plonk, innerCcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	innerWitness, err := frontend.NewWitness(&innerAssignment, innerField)
+	if err != nil {
+		t.Fatal(err)
+	}
+	innerPublicWitness, err := innerWitness.Public()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	innerProof, err := backend.Prove(backend.Plonk, innerCcs, innerPK, innerWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	circuitWrongVK, err := stdplonk.ValueOfVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](wrongVK.(plonk.VerifyingKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	circuitWitness, err := stdplonk.ValueOfWitness[sw_bn254.ScalarField](innerPublicWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	circuitProof, err := stdplonk.ValueOfProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerProof.(plonk.Proof))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outerCircuit := &RecursiveEdDSACircuit{
+		Proof:        stdplonk.PlaceholderProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		VerifyingKey: stdplonk.PlaceholderVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		InnerWitness: stdplonk.PlaceholderWitness[sw_bn254.ScalarField](innerCcs),
+	}
+	outerAssignment := &RecursiveEdDSACircuit{
+		Proof:        circuitProof,
+		VerifyingKey: circuitWrongVK,
+		InnerWitness: circuitWitness,
+	}
+
+	outerField := ecc.BW6_761.ScalarField()
+	if err := test.IsSolved(outerCircuit, outerAssignment, outerField); err == nil {
+		t.Fatal("Test failed: proof was accepted against a different inner verifying key")
+	}
+}
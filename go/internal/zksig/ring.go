@@ -0,0 +1,292 @@
+package zksig
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	gnarkhash "github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/emulated"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+)
+
+var errMismatchedRingWitness = errors.New("zksig: Path and Index must have the same length")
+
+// KeySetTree is a binary Merkle tree of public keys, built off-circuit
+// over MiMC on the SNARK scalar field. EdDSARingCircuit/ECDSARingCircuit
+// use it in place of the linear KeyList scan EdDSACircuit/ECDSACircuit
+// perform, with an O(log n) membership proof, so the anonymity set can
+// grow to 2^20+ keys without the circuit (and its CRS) growing with it.
+//
+// Leaves are padded with zero up to the next power of two. Levels[0] holds
+// the leaves, Levels[len(Levels)-1] holds the single root.
+type KeySetTree struct {
+	Depth  int
+	Levels [][]*big.Int
+}
+
+// NewKeySetTree builds a KeySetTree over the given leaves, hashing each
+// adjacent pair with MiMC (BN254) up to the root.
+func NewKeySetTree(leaves []*big.Int) *KeySetTree {
+	size, depth := 1, 0
+	for size < len(leaves) || depth == 0 {
+		size *= 2
+		depth++
+	}
+
+	level0 := make([]*big.Int, size)
+	for i := range level0 {
+		if i < len(leaves) {
+			level0[i] = leaves[i]
+		} else {
+			level0[i] = new(big.Int)
+		}
+	}
+
+	levels := [][]*big.Int{level0}
+	for len(levels[len(levels)-1]) > 1 {
+		prev := levels[len(levels)-1]
+		next := make([]*big.Int, len(prev)/2)
+		for i := range next {
+			next[i] = mimcHash(prev[2*i], prev[2*i+1])
+		}
+		levels = append(levels, next)
+	}
+
+	return &KeySetTree{Depth: depth, Levels: levels}
+}
+
+// Root returns the Merkle root of the tree.
+func (t *KeySetTree) Root() *big.Int {
+	return t.Levels[len(t.Levels)-1][0]
+}
+
+// Path returns, for the leaf at index, the sibling hash at every level
+// (bottom-up) together with the bit-decomposed index (LSB first) that the
+// ring circuits use to choose the left/right hashing order at each level.
+func (t *KeySetTree) Path(index int) (path []*big.Int, indexBits []*big.Int) {
+	path = make([]*big.Int, t.Depth)
+	indexBits = make([]*big.Int, t.Depth)
+	cur := index
+	for level := 0; level < t.Depth; level++ {
+		path[level] = t.Levels[level][cur^1]
+		indexBits[level] = big.NewInt(int64(cur & 1))
+		cur /= 2
+	}
+	return path, indexBits
+}
+
+// mimcHashLimbs combines an arbitrary sequence of native field elements
+// with MiMC (BN254), matching the hash the in-circuit mimc.MiMC gadget
+// computes over the same sequence via Write(values...)/Sum().
+func mimcHashLimbs(values ...*big.Int) *big.Int {
+	h := gnarkhash.MIMC_BN254.New()
+	buf := make([]byte, 32)
+	for _, v := range values {
+		v.FillBytes(buf)
+		h.Write(buf)
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// mimcHash combines two field elements with MiMC (BN254), matching the
+// hash the in-circuit mimc.MiMC gadget computes over the same two limbs.
+func mimcHash(left, right *big.Int) *big.Int {
+	return mimcHashLimbs(left, right)
+}
+
+// limbsOf decomposes v into the same non-native limb representation
+// emulated.ValueOf[T] produces, i.e. the representation PublicKey.X/Y
+// carry once assigned into a witness, so an off-circuit hash over those
+// limbs matches what the in-circuit gadget sees.
+func limbsOf[T emulated.FieldParams](v *big.Int) []*big.Int {
+	elem := emulated.ValueOf[T](v)
+	limbs := make([]*big.Int, len(elem.Limbs))
+	for i, l := range elem.Limbs {
+		limbs[i] = l.(*big.Int)
+	}
+	return limbs
+}
+
+// ecdsaLeafHash mirrors ECDSARingCircuit's leaf hash
+// MiMC(PublicKey.X.Limbs..., PublicKey.Y.Limbs...), hashing the same
+// limb decomposition off-circuit instead of the two full coordinates (the
+// bug BuildECDSAMembershipWitness used to have: the leaves it built never
+// matched what the circuit recomputed).
+func ecdsaLeafHash[T emulated.FieldParams](x, y *big.Int) *big.Int {
+	return mimcHashLimbs(append(limbsOf[T](x), limbsOf[T](y)...)...)
+}
+
+// EmulatedAffine is the off-circuit analogue of a non-native elliptic
+// curve public key: a plain (X, Y) coordinate pair over the base field,
+// used to build/hash a KeySetTree before the ECDSA witness is assigned in
+// its emulated representation.
+type EmulatedAffine struct {
+	X, Y *big.Int
+}
+
+// hashEdDSAPublicKey turns a signature.PublicKey into a Merkle leaf by
+// MiMC-hashing its affine twisted Edwards coordinates.
+func hashEdDSAPublicKey(pk signature.PublicKey) (*big.Int, error) {
+	pub, ok := pk.(*eddsa.PublicKey)
+	if !ok {
+		return nil, errors.New("zksig: expected an eddsa.PublicKey")
+	}
+	x := new(big.Int)
+	y := new(big.Int)
+	pub.A.X.BigInt(x)
+	pub.A.Y.BigInt(y)
+	return mimcHash(x, y), nil
+}
+
+// BuildEdDSAMembershipWitness hashes every key in keyList into a
+// KeySetTree and returns the root plus the Merkle path/index witness for
+// keyList[chosenIndex], so an EdDSARingCircuit can prove membership in
+// O(log n) constraints rather than embedding the whole list in the circuit.
+func BuildEdDSAMembershipWitness(keyList []signature.PublicKey, chosenIndex int) (root *big.Int, path []*big.Int, index []*big.Int, err error) {
+	leaves := make([]*big.Int, len(keyList))
+	for i, pk := range keyList {
+		leaves[i], err = hashEdDSAPublicKey(pk)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	tree := NewKeySetTree(leaves)
+	path, index = tree.Path(chosenIndex)
+	return tree.Root(), path, index, nil
+}
+
+// BuildECDSAMembershipWitness hashes the (X, Y) coordinates of every key
+// in keyList, under the same non-native limb decomposition field T uses,
+// into a KeySetTree and returns the root plus the Merkle path/index
+// witness for keyList[chosenIndex].
+func BuildECDSAMembershipWitness[T emulated.FieldParams](keyList []EmulatedAffine, chosenIndex int) (root *big.Int, path []*big.Int, index []*big.Int) {
+	leaves := make([]*big.Int, len(keyList))
+	for i, key := range keyList {
+		leaves[i] = ecdsaLeafHash[T](key.X, key.Y)
+	}
+	tree := NewKeySetTree(leaves)
+	path, index = tree.Path(chosenIndex)
+	return tree.Root(), path, index
+}
+
+// EdDSARingCircuit proves that the secret PublicKey behind Signature is a
+// member of a public Merkle Root of keys, in O(log n) constraints instead
+// of the O(n) NAND loop in EdDSACircuit.
+type EdDSARingCircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	PublicKey stdeddsa.PublicKey  `gnark:",secret"`
+	Signature stdeddsa.Signature  `gnark:",public"`
+	Message   frontend.Variable   `gnark:",public"`
+	Root      frontend.Variable   `gnark:",public"`
+	Path      []frontend.Variable `gnark:",secret"`
+	Index     []frontend.Variable `gnark:",secret"`
+}
+
+// Define declares the circuit constraints: verify the EdDSA signature
+// under PublicKey, then recompute the Merkle root of the leaf
+// MiMC(PublicKey.X, PublicKey.Y) against the secret Path/Index witness
+// and assert it matches the public Root.
+func (circuit *EdDSARingCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+
+	sigHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	if err := stdeddsa.Verify(curve, circuit.Signature, circuit.Message, circuit.PublicKey, &sigHash); err != nil {
+		return err
+	}
+
+	if len(circuit.Path) != len(circuit.Index) {
+		return errMismatchedRingWitness
+	}
+
+	leafHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	leafHash.Write(circuit.PublicKey.A.X, circuit.PublicKey.A.Y)
+	cur := leafHash.Sum()
+
+	for level := range circuit.Path {
+		api.AssertIsBoolean(circuit.Index[level])
+		sibling := circuit.Path[level]
+		left := api.Select(circuit.Index[level], sibling, cur)
+		right := api.Select(circuit.Index[level], cur, sibling)
+
+		levelHash, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		levelHash.Write(left, right)
+		cur = levelHash.Sum()
+	}
+
+	api.AssertIsEqual(cur, circuit.Root)
+	return nil
+}
+
+// ECDSARingCircuit proves that the secret PublicKey behind Signature is a
+// member of a public Merkle Root of keys, in O(log n) constraints instead
+// of the O(n) NAND loop in ECDSACircuit. The leaf hash is taken over the
+// non-native limbs of PublicKey.X/Y, since MiMC operates on the native
+// SNARK field.
+type ECDSARingCircuit[T, S emulated.FieldParams] struct {
+	curveID ecc.ID `gnark:",secret"`
+
+	PublicKey stdecdsa.PublicKey[T, S] `gnark:",secret"`
+	Signature stdecdsa.Signature[S]    `gnark:",public"`
+	Message   emulated.Element[S]      `gnark:",public"`
+	Root      frontend.Variable        `gnark:",public"`
+	Path      []frontend.Variable      `gnark:",secret"`
+	Index     []frontend.Variable      `gnark:",secret"`
+}
+
+// Define declares the circuit constraints: verify the ECDSA signature
+// under PublicKey, then recompute the Merkle root of the leaf
+// MiMC(PublicKey.X.Limbs..., PublicKey.Y.Limbs...) against the secret
+// Path/Index witness and assert it matches the public Root.
+func (circuit *ECDSARingCircuit[T, S]) Define(api frontend.API) error {
+	if len(circuit.Path) != len(circuit.Index) {
+		return errMismatchedRingWitness
+	}
+
+	leafHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	leafHash.Write(circuit.PublicKey.X.Limbs...)
+	leafHash.Write(circuit.PublicKey.Y.Limbs...)
+	cur := leafHash.Sum()
+
+	for level := range circuit.Path {
+		api.AssertIsBoolean(circuit.Index[level])
+		sibling := circuit.Path[level]
+		left := api.Select(circuit.Index[level], sibling, cur)
+		right := api.Select(circuit.Index[level], cur, sibling)
+
+		levelHash, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		levelHash.Write(left, right)
+		cur = levelHash.Sum()
+	}
+	api.AssertIsEqual(cur, circuit.Root)
+
+	circuit.PublicKey.Verify(api, sw_emulated.GetCurveParams[T](), &circuit.Message, &circuit.Signature)
+	return nil
+}
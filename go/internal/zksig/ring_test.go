@@ -0,0 +1,161 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+func TestEdDSARing(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+	mathRandomness := rand.New(rand.NewSource(time.Now().Unix()))
+
+	// Build an anonymity set of a handful of keys; the Merkle approach
+	// pays off at large n but the same circuit works for any size.
+	const setSize = 5
+	const usedIndex = 2
+
+	keyList := make([]signature.PublicKey, setSize)
+	var usedKey signature.Signer
+	for i := range keyList {
+		key, err := eddsa.New(curve, cryptoRandomness)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyList[i] = key.Public()
+		if i == usedIndex {
+			usedKey = key
+		}
+	}
+	usedPublicKey := usedKey.Public()
+
+	var msg big.Int
+	msg.Rand(mathRandomness, snarkField)
+	msgDataUnpadded := msg.Bytes()
+	msgData := make([]byte, len(snarkField.Bytes()))
+	copy(msgData[len(msgData)-len(msgDataUnpadded):], msgDataUnpadded)
+
+	sig, err := usedKey.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := usedPublicKey.Verify(sig, msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValid {
+		t.Fatal("Invalid signature!")
+	}
+
+	root, path, index, err := BuildEdDSAMembershipWitness(keyList, usedIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	circuit := EdDSARingCircuit{
+		curveID: curve,
+		Path:    make([]frontend.Variable, len(path)),
+		Index:   make([]frontend.Variable, len(index)),
+	}
+
+	witnessAssignment := EdDSARingCircuit{
+		Message: msg,
+		Root:    root,
+		Path:    make([]frontend.Variable, len(path)),
+		Index:   make([]frontend.Variable, len(index)),
+	}
+	witnessAssignment.PublicKey.Assign(curve, usedPublicKey.Bytes())
+	witnessAssignment.Signature.Assign(curve, sig)
+	for i := range path {
+		witnessAssignment.Path[i] = path[i]
+		witnessAssignment.Index[i] = index[i]
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBadEdDSARing(t *testing.T) {
+	const curve = tedwards.BN254
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashFunction := hash.MIMC_BN254
+	cryptoRandomness := cryptorand.Reader
+	mathRandomness := rand.New(rand.NewSource(time.Now().Unix()))
+
+	const setSize = 5
+	const usedIndex = 2
+
+	keyList := make([]signature.PublicKey, setSize)
+	var usedKey signature.Signer
+	for i := range keyList {
+		key, err := eddsa.New(curve, cryptoRandomness)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyList[i] = key.Public()
+		if i == usedIndex {
+			usedKey = key
+		}
+	}
+	usedPublicKey := usedKey.Public()
+
+	var msg big.Int
+	msg.Rand(mathRandomness, snarkField)
+	msgDataUnpadded := msg.Bytes()
+	msgData := make([]byte, len(snarkField.Bytes()))
+	copy(msgData[len(msgData)-len(msgDataUnpadded):], msgDataUnpadded)
+
+	sig, err := usedKey.Sign(msgData, hashFunction.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build the membership witness for a key that was NOT used to sign.
+	root, path, index, err := BuildEdDSAMembershipWitness(keyList, (usedIndex+1)%setSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	circuit := EdDSARingCircuit{
+		curveID: curve,
+		Path:    make([]frontend.Variable, len(path)),
+		Index:   make([]frontend.Variable, len(index)),
+	}
+
+	witnessAssignment := EdDSARingCircuit{
+		Message: msg,
+		Root:    root,
+		Path:    make([]frontend.Variable, len(path)),
+		Index:   make([]frontend.Variable, len(index)),
+	}
+	witnessAssignment.PublicKey.Assign(curve, usedPublicKey.Bytes())
+	witnessAssignment.Signature.Assign(curve, sig)
+	for i := range path {
+		witnessAssignment.Path[i] = path[i]
+		witnessAssignment.Index[i] = index[i]
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err == nil {
+		t.Fatal("Test failed: proof was successful while it should not be")
+	}
+}
@@ -0,0 +1,275 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"log"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+)
+
+// SchnorrSignature is a (R, s) Schnorr signature over the same emulated
+// short Weierstrass curve ECDSACircuit's keys live on: R is the nonce
+// commitment point and s the response scalar.
+type SchnorrSignature[T, S emulated.FieldParams] struct {
+	R stdecdsa.PublicKey[T, S]
+	S emulated.Element[S]
+}
+
+// SchnorrCircuit proves knowledge of the private key behind a Schnorr
+// signature over an emulated short Weierstrass curve, alongside
+// ECDSACircuit's secp256k1/P-256/P-384 support. Unlike EdDSACircuit (which
+// is pinned to the BN254-native twisted Edwards curve), SchnorrCircuit is
+// generic over the same curve family as ECDSACircuit.
+type SchnorrCircuit[T, S emulated.FieldParams] struct {
+	curveID   ecc.ID                     `gnark:",secret"`
+	PublicKey stdecdsa.PublicKey[T, S]   `gnark:",secret"`
+	Signature SchnorrSignature[T, S]     `gnark:",public"`
+	Message   []uints.U8                 `gnark:",public"`
+	KeyList   []stdecdsa.PublicKey[T, S] `gnark:",public"`
+}
+
+// Define declares the circuit constraints: the KeyList membership check
+// from ECDSACircuit, followed by the Schnorr equation s*G = R + e*P where
+// e is a MiMC-based challenge binding R, the public key and the message.
+func (circuit *SchnorrCircuit[T, S]) Define(api frontend.API) error {
+	emulatedField, err := emulated.NewField[T](api)
+	if err != nil {
+		return err
+	}
+	// start with isUnknownKey at 1, meaning that the key is unknown
+	var isUnknownKey frontend.Variable = 1
+	for _, key := range circuit.KeyList {
+		equalX := emulatedField.IsZero(emulatedField.Sub(&circuit.PublicKey.X, &key.X))
+		api.AssertIsBoolean(equalX)
+		equalY := emulatedField.IsZero(emulatedField.Sub(&circuit.PublicKey.Y, &key.Y))
+		api.AssertIsBoolean(equalY)
+		differentKey := api.IsZero(api.And(equalX, equalY))
+		api.AssertIsBoolean(differentKey)
+		isUnknownKey = api.Mul(isUnknownKey, differentKey)
+		api.AssertIsBoolean(isUnknownKey)
+	}
+	api.AssertIsEqual(isUnknownKey, 0)
+
+	// Challenge e = MiMC(R.X, R.Y, PublicKey.X, PublicKey.Y, digestValue),
+	// shared with ThresholdSchnorrCircuit so the two can't drift apart the
+	// way they did before challengeScalar was brought in line with this.
+	e, err := schnorrChallenge[T, S](api, circuit.Message, circuit.Signature.R.X, circuit.Signature.R.Y, circuit.PublicKey.X, circuit.PublicKey.Y)
+	if err != nil {
+		return err
+	}
+
+	curve, err := sw_emulated.New[T, S](api, sw_emulated.GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	p := sw_emulated.AffinePoint[T]{X: circuit.PublicKey.X, Y: circuit.PublicKey.Y}
+	r := sw_emulated.AffinePoint[T]{X: circuit.Signature.R.X, Y: circuit.Signature.R.Y}
+
+	lhs := curve.ScalarMulBase(&circuit.Signature.S)
+	rhs := curve.AddUnified(&r, curve.ScalarMul(&p, e))
+	curve.AssertIsEqual(lhs, rhs)
+
+	return nil
+}
+
+// schnorrChallenge computes the Schnorr challenge e =
+// MiMC(R.X.Limbs, R.Y.Limbs, P.X.Limbs, P.Y.Limbs, digestValue) reduced mod
+// the scalar field S, where digestValue folds a SHA-256 hash of message
+// into a single native-field variable. SchnorrCircuit and
+// ThresholdSchnorrCircuit both call this so their challenge computation
+// can't silently diverge.
+func schnorrChallenge[T, S emulated.FieldParams](api frontend.API, message []uints.U8, rX, rY, pX, pY emulated.Element[T]) (*emulated.Element[S], error) {
+	hasher, err := sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	hasher.Write(message)
+	digest := hasher.Sum()
+	var digestValue frontend.Variable = 0
+	for _, b := range digest {
+		digestValue = api.Add(api.Mul(digestValue, 256), b.Val)
+	}
+
+	challengeHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return nil, err
+	}
+	challengeHash.Write(rX.Limbs...)
+	challengeHash.Write(rY.Limbs...)
+	challengeHash.Write(pX.Limbs...)
+	challengeHash.Write(pY.Limbs...)
+	challengeHash.Write(digestValue)
+
+	scalarField, err := emulated.NewField[S](api)
+	if err != nil {
+		return nil, err
+	}
+	return scalarField.NewElement(challengeHash.Sum()), nil
+}
+
+// signSchnorr produces a SchnorrSignature (off-circuit, over secp256k1)
+// for msgData under priv, using the same R/P/message MiMC challenge
+// SchnorrCircuit.Define computes, so RunSchnorr/tests can build matching
+// witnesses. This is not a standardized scheme (e.g. not BIP-340) - it
+// exists to exercise SchnorrCircuit with a real elliptic-curve signature.
+func signSchnorr(priv *big.Int, pub secp256k1.G1Affine, msgData []byte) (r secp256k1.G1Affine, s *big.Int, err error) {
+	nonce, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		return secp256k1.G1Affine{}, nil, err
+	}
+	_, _, g1Gen, _ := secp256k1.Generators()
+	r.ScalarMultiplication(&g1Gen, nonce)
+
+	digest := sha256.Sum256(msgData)
+	e := challengeScalar(r, pub, digest[:])
+
+	// s = nonce + e*priv (mod order)
+	s = new(big.Int).Mul(e, priv)
+	s.Add(s, nonce)
+	s.Mod(s, fr.Modulus())
+	return r, s, nil
+}
+
+// verifySchnorr checks s*G == R + e*P, the same equation Define asserts.
+func verifySchnorr(pub secp256k1.G1Affine, r secp256k1.G1Affine, s *big.Int, msgData []byte) bool {
+	_, _, g1Gen, _ := secp256k1.Generators()
+	var lhs secp256k1.G1Affine
+	lhs.ScalarMultiplication(&g1Gen, s)
+
+	digest := sha256.Sum256(msgData)
+	e := challengeScalar(r, pub, digest[:])
+
+	var eP, rhs secp256k1.G1Affine
+	eP.ScalarMultiplication(&pub, e)
+	rhs.Add(&r, &eP)
+
+	return lhs.Equal(&rhs)
+}
+
+// challengeScalar mirrors the in-circuit challenge exactly: a single flat
+// MiMC sponge (not a nested hash-of-hashes) over R.X.Limbs, R.Y.Limbs,
+// P.X.Limbs, P.Y.Limbs and digestValue, in that order, using the same
+// non-native limb decomposition emulated.ValueOf[Secp256k1Fp] produces for
+// R/P once assigned into a witness. digestValue mirrors the in-circuit
+// Horner fold of the SHA-256 digest bytes into a single native-field
+// variable, which is implicitly reduced mod the native BN254 scalar field
+// as it accumulates - so it's reduced the same way here, not left as the
+// raw 256-bit digest.
+func challengeScalar(r, pub secp256k1.G1Affine, digest []byte) *big.Int {
+	rx, ry, px, py := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	r.X.BigInt(rx)
+	r.Y.BigInt(ry)
+	pub.X.BigInt(px)
+	pub.Y.BigInt(py)
+
+	digestValue := new(big.Int).Mod(new(big.Int).SetBytes(digest), ecc.BN254.ScalarField())
+
+	values := append(limbsOf[emulated.Secp256k1Fp](rx), limbsOf[emulated.Secp256k1Fp](ry)...)
+	values = append(values, limbsOf[emulated.Secp256k1Fp](px)...)
+	values = append(values, limbsOf[emulated.Secp256k1Fp](py)...)
+	values = append(values, digestValue)
+
+	e := mimcHashLimbs(values...)
+	return new(big.Int).Mod(e, fr.Modulus())
+}
+
+func RunSchnorr() {
+	const curve = ecc.BN254
+	snarkField := curve.ScalarField()
+
+	priv, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, _, g1Gen, _ := secp256k1.Generators()
+	var pub secp256k1.G1Affine
+	pub.ScalarMultiplication(&g1Gen, priv)
+
+	differentPriv, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		log.Fatal(err)
+	}
+	var differentPub secp256k1.G1Affine
+	differentPub.ScalarMultiplication(&g1Gen, differentPriv)
+
+	msgData := []byte("this is a Schnorr test message")
+	r, s, err := signSchnorr(priv, pub, msgData)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !verifySchnorr(pub, r, s, msgData) {
+		log.Fatal("Invalid signature!")
+	}
+
+	usedPublicKey := assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](pub)
+	differentPublicKey := assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](differentPub)
+	keyList := []stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{differentPublicKey, usedPublicKey}
+
+	circuit := SchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+	r1cs, err := frontend.Compile(snarkField, r1cs.NewBuilder, &circuit)
+	if err != nil {
+		log.Fatal("Failed to compile circuit! ", err)
+	}
+
+	pk, vk, err := groth16.Setup(r1cs)
+	if err != nil {
+		log.Fatal("Failed to perform setup! ", err)
+	}
+
+	assignment := SchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Signature: SchnorrSignature[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			R: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: usedPublicKey,
+		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+	for i, pk := range keyList {
+		assignment.KeyList[i] = pk
+	}
+
+	witness, err := frontend.NewWitness(&assignment, snarkField)
+	if err != nil {
+		log.Fatal("Failed to generate witness! ", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		log.Fatal("Failed to obtain public witness! ", err)
+	}
+
+	proof, err := groth16.Prove(r1cs, pk, witness)
+	if err != nil {
+		log.Fatal("Failed to generate proof! ", err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		log.Fatal("Failed to verify proof! ", err)
+	}
+}
+
+func assignSchnorrKey[T, S emulated.FieldParams](p secp256k1.G1Affine) stdecdsa.PublicKey[T, S] {
+	x, y := new(big.Int), new(big.Int)
+	p.X.BigInt(x)
+	p.Y.BigInt(y)
+	return stdecdsa.PublicKey[T, S]{
+		X: emulated.ValueOf[T](x),
+		Y: emulated.ValueOf[T](y),
+	}
+}
@@ -0,0 +1,130 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+	"github.com/consensys/gnark/test"
+)
+
+func TestSchnorr(t *testing.T) {
+	// Define the curve to use
+	const curve = ecc.BN254
+	// Obtain the corresponding snarkField
+	snarkField := curve.ScalarField()
+
+	priv, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, g1Gen, _ := secp256k1.Generators()
+	var usedPub secp256k1.G1Affine
+	usedPub.ScalarMultiplication(&g1Gen, priv)
+	usedPublicKey := assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedPub)
+
+	differentPriv, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var differentPub secp256k1.G1Affine
+	differentPub.ScalarMultiplication(&g1Gen, differentPriv)
+	differentPublicKey := assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](differentPub)
+
+	keyList := []stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{differentPublicKey, usedPublicKey}
+
+	msgData := []byte("this is a Schnorr test message")
+	r, s, err := signSchnorr(priv, usedPub, msgData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifySchnorr(usedPub, r, s, msgData) {
+		t.Fatal("Invalid signature!")
+	}
+
+	circuit := SchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+
+	witnessAssignment := SchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Signature: SchnorrSignature[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			R: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: usedPublicKey,
+		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+	for i, pk := range keyList {
+		witnessAssignment.KeyList[i] = pk
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBadSchnorr(t *testing.T) {
+	// Define the curve to use
+	const curve = ecc.BN254
+	// Obtain the corresponding snarkField
+	snarkField := curve.ScalarField()
+
+	priv, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, g1Gen, _ := secp256k1.Generators()
+	var usedPub secp256k1.G1Affine
+	usedPub.ScalarMultiplication(&g1Gen, priv)
+
+	differentPriv, err := cryptorand.Int(cryptorand.Reader, fr.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var differentPub secp256k1.G1Affine
+	differentPub.ScalarMultiplication(&g1Gen, differentPriv)
+	differentPublicKey := assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](differentPub)
+
+	// Add just the different public key to the key list
+	keyList := []stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{differentPublicKey}
+
+	msgData := []byte("this is a Schnorr test message")
+	r, s, err := signSchnorr(priv, usedPub, msgData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifySchnorr(usedPub, r, s, msgData) {
+		t.Fatal("Invalid signature!")
+	}
+
+	circuit := SchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		KeyList: make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+
+	witnessAssignment := SchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Signature: SchnorrSignature[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			R: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Message:   uints.NewU8Array(msgData),
+		PublicKey: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](usedPub),
+		KeyList:   make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], len(keyList)),
+	}
+	for i, pk := range keyList {
+		witnessAssignment.KeyList[i] = pk
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err == nil {
+		t.Fatal("Test failed: proof was successful while it should not be")
+	}
+}
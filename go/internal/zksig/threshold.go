@@ -0,0 +1,354 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"log"
+	"math/big"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+)
+
+// ThresholdEdDSACircuit verifies a (t,n)-threshold Schnorr signature
+// aggregated per the Stinson-Strobl distributed-Schnorr construction: the
+// group public key Y is reconstructed in-circuit as Σ λ_i·Y_i from the
+// signing subset's individual verification shares Y_i and Lagrange
+// coefficients λ_i, then the ordinary Schnorr/EdDSA check is run against
+// the reconstructed Y. A verifier is convinced a valid quorum signed
+// Message without learning which t-of-n participants were in the subset.
+type ThresholdEdDSACircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	GroupKey  stdeddsa.PublicKey `gnark:",public"`
+	Signature stdeddsa.Signature `gnark:",public"`
+	Message   frontend.Variable  `gnark:",public"`
+
+	// Shares/Lambdas are the signing subset's verification shares Y_i
+	// and their Lagrange coefficients λ_i, kept secret so the verifier
+	// cannot tell which participants signed.
+	Shares  []stdeddsa.PublicKey `gnark:",secret"`
+	Lambdas []frontend.Variable  `gnark:",secret"`
+}
+
+// Define declares the circuit constraints: reconstruct the group key via
+// the weighted sum Σ λ_i·Y_i, assert it matches the public GroupKey, and
+// run the standard Schnorr/EdDSA verification equation against it.
+func (circuit *ThresholdEdDSACircuit) Define(api frontend.API) error {
+	if len(circuit.Shares) != len(circuit.Lambdas) {
+		return errThresholdWitnessMismatch
+	}
+	if len(circuit.Shares) == 0 {
+		return errThresholdEmptySubset
+	}
+
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+	gcParams, err := tedwards.GetCurveParams(circuit.curveID)
+	if err != nil {
+		return err
+	}
+	base := twistededwards.Point{X: gcParams.Base.X, Y: gcParams.Base.Y}
+
+	reconstructed, err := weightedSum(curve, circuit.Shares, circuit.Lambdas)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(reconstructed.X, circuit.GroupKey.A.X)
+	api.AssertIsEqual(reconstructed.Y, circuit.GroupKey.A.Y)
+
+	// Derive the challenge the same way ThresholdEdDSAPartialCircuit does,
+	// rather than delegating to stdeddsa.Verify: that keeps both circuits,
+	// and RunThresholdEdDSA's off-circuit signer, on one fully-specified,
+	// self-consistent challenge formula instead of depending on
+	// stdeddsa.Verify's internal hRAM byte ordering.
+	challengeHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	challengeHash.Write(circuit.Signature.R.X, circuit.Signature.R.Y, circuit.GroupKey.A.X, circuit.GroupKey.A.Y, circuit.Message)
+	c := challengeHash.Sum()
+
+	r := twistededwards.Point{X: circuit.Signature.R.X, Y: circuit.Signature.R.Y}
+	assertPartial(api, curve, base, r, circuit.Signature.S, circuit.GroupKey, c)
+	return nil
+}
+
+// ThresholdEdDSAPartialCircuit validates the t individual partial
+// signatures that make up an aggregate, rather than just the aggregate
+// itself: each participant's partial signature s_i must satisfy the
+// Schnorr equation against their own nonce share R_i and verification
+// share Y_i under the shared challenge, and the partials must combine
+// (weighted by the same Lagrange coefficients) into the public aggregate
+// (AggregateR, AggregateS).
+type ThresholdEdDSAPartialCircuit struct {
+	curveID tedwards.ID `gnark:",secret"`
+
+	GroupKey   stdeddsa.PublicKey   `gnark:",public"`
+	Message    frontend.Variable    `gnark:",public"`
+	AggregateR twistededwards.Point `gnark:",public"`
+	AggregateS frontend.Variable    `gnark:",public"`
+
+	Shares            []stdeddsa.PublicKey   `gnark:",secret"`
+	Lambdas           []frontend.Variable    `gnark:",secret"`
+	NonceShares       []twistededwards.Point `gnark:",secret"`
+	PartialSignatures []frontend.Variable    `gnark:",secret"`
+}
+
+// Define declares the circuit constraints: reconstruct the group key,
+// derive the shared challenge c = MiMC(R, Y, M) from the aggregate nonce,
+// check every partial signature s_i·G == R_i + c·Y_i, and check that the
+// nonce shares and partial signatures combine into the public aggregate.
+func (circuit *ThresholdEdDSAPartialCircuit) Define(api frontend.API) error {
+	n := len(circuit.Shares)
+	if n != len(circuit.Lambdas) || n != len(circuit.NonceShares) || n != len(circuit.PartialSignatures) {
+		return errThresholdWitnessMismatch
+	}
+	if n == 0 {
+		return errThresholdEmptySubset
+	}
+
+	curve, err := twistededwards.NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+	gcParams, err := tedwards.GetCurveParams(circuit.curveID)
+	if err != nil {
+		return err
+	}
+	base := twistededwards.Point{X: gcParams.Base.X, Y: gcParams.Base.Y}
+
+	reconstructed, err := weightedSum(curve, circuit.Shares, circuit.Lambdas)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(reconstructed.X, circuit.GroupKey.A.X)
+	api.AssertIsEqual(reconstructed.Y, circuit.GroupKey.A.Y)
+
+	challengeHash, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	challengeHash.Write(circuit.AggregateR.X, circuit.AggregateR.Y, circuit.GroupKey.A.X, circuit.GroupKey.A.Y, circuit.Message)
+	c := challengeHash.Sum()
+
+	nonceSum := circuit.NonceShares[0]
+	weightedS := api.Mul(circuit.Lambdas[0], circuit.PartialSignatures[0])
+	assertPartial(api, curve, base, circuit.NonceShares[0], circuit.PartialSignatures[0], circuit.Shares[0], c)
+
+	for i := 1; i < n; i++ {
+		assertPartial(api, curve, base, circuit.NonceShares[i], circuit.PartialSignatures[i], circuit.Shares[i], c)
+		nonceSum = curve.Add(nonceSum, circuit.NonceShares[i])
+		weightedS = api.Add(weightedS, api.Mul(circuit.Lambdas[i], circuit.PartialSignatures[i]))
+	}
+
+	api.AssertIsEqual(nonceSum.X, circuit.AggregateR.X)
+	api.AssertIsEqual(nonceSum.Y, circuit.AggregateR.Y)
+	api.AssertIsEqual(weightedS, circuit.AggregateS)
+	return nil
+}
+
+// assertPartial checks the per-participant Schnorr equation
+// s_i·G == R_i + c·Y_i.
+func assertPartial(api frontend.API, curve twistededwards.Curve, base twistededwards.Point, nonceShare twistededwards.Point, partialS frontend.Variable, share stdeddsa.PublicKey, c frontend.Variable) {
+	lhs := curve.ScalarMul(base, partialS)
+	rhs := curve.Add(nonceShare, curve.ScalarMul(twistededwards.Point{X: share.A.X, Y: share.A.Y}, c))
+	api.AssertIsEqual(lhs.X, rhs.X)
+	api.AssertIsEqual(lhs.Y, rhs.Y)
+}
+
+// weightedSum computes Σ shares_i·lambdas_i over the twisted Edwards
+// curve, i.e. the in-circuit multi-scalar multiplication used to
+// reconstruct a group key (or an aggregate nonce) from a signing subset.
+func weightedSum(curve twistededwards.Curve, shares []stdeddsa.PublicKey, lambdas []frontend.Variable) (twistededwards.Point, error) {
+	sum := curve.ScalarMul(twistededwards.Point{X: shares[0].A.X, Y: shares[0].A.Y}, lambdas[0])
+	for i := 1; i < len(shares); i++ {
+		term := curve.ScalarMul(twistededwards.Point{X: shares[i].A.X, Y: shares[i].A.Y}, lambdas[i])
+		sum = curve.Add(sum, term)
+	}
+	return sum, nil
+}
+
+var errThresholdWitnessMismatch = thresholdError("zksig: Shares, Lambdas and related witness slices must have the same length")
+var errThresholdEmptySubset = thresholdError("zksig: threshold signing subset must not be empty")
+
+type thresholdError string
+
+func (e thresholdError) Error() string { return string(e) }
+
+// LagrangeCoefficients computes, for each index in indices, the Lagrange
+// coefficient λ_i = Π_{j≠i} (0 - idx_j) / (idx_i - idx_j) mod order used
+// to reconstruct a Shamir-shared secret (or its associated public key)
+// at x = 0 from exactly the given signing subset.
+func LagrangeCoefficients(indices []int, order *big.Int) []*big.Int {
+	lambdas := make([]*big.Int, len(indices))
+	for i, idxI := range indices {
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+		for j, idxJ := range indices {
+			if i == j {
+				continue
+			}
+			num.Mul(num, big.NewInt(int64(-idxJ)))
+			num.Mod(num, order)
+			den.Mul(den, big.NewInt(int64(idxI-idxJ)))
+			den.Mod(den, order)
+		}
+		denInv := new(big.Int).ModInverse(den, order)
+		lambdas[i] = new(big.Int).Mul(num, denInv)
+		lambdas[i].Mod(lambdas[i], order)
+	}
+	return lambdas
+}
+
+// shamirShares splits secret into n shares over a random polynomial of
+// degree t-1, evaluated at x = 1..n, so that any t of them reconstruct
+// secret via LagrangeCoefficients.
+func shamirShares(secret *big.Int, t, n int, order *big.Int) []*big.Int {
+	coeffs := make([]*big.Int, t)
+	coeffs[0] = secret
+	for i := 1; i < t; i++ {
+		c, err := cryptorand.Int(cryptorand.Reader, order)
+		if err != nil {
+			log.Fatal(err)
+		}
+		coeffs[i] = c
+	}
+
+	shares := make([]*big.Int, n)
+	for x := 1; x <= n; x++ {
+		share := new(big.Int)
+		xPow := big.NewInt(1)
+		bigX := big.NewInt(int64(x))
+		for _, c := range coeffs {
+			term := new(big.Int).Mul(c, xPow)
+			share.Add(share, term)
+			share.Mod(share, order)
+			xPow.Mul(xPow, bigX)
+			xPow.Mod(xPow, order)
+		}
+		shares[x-1] = share
+	}
+	return shares
+}
+
+// RunThresholdEdDSA mirrors RunEdDSA: it Shamir-shares a group signing
+// key among n participants, signs Message with the group secret those
+// shares reconstruct, and proves - via ThresholdEdDSACircuit, from the
+// verification shares and Lagrange coefficients of a t-participant
+// subset - that the signature verifies under the group key that subset
+// reconstructs, without revealing which participants were in it. The
+// participants do not compute partial signatures here.
+func RunThresholdEdDSA() {
+	const curve = tedwards.BN254
+	const t, n = 3, 5
+
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		log.Fatal(err)
+	}
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	groupSecret, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var groupKey tedwards.PointAffine
+	groupKey.ScalarMultiplication(&params.Base, groupSecret)
+
+	secretShares := shamirShares(groupSecret, t, n, params.Order)
+
+	// The first t participants (indices 1..t) form the signing subset.
+	signingIndices := make([]int, t)
+	for i := range signingIndices {
+		signingIndices[i] = i + 1
+	}
+	lambdas := LagrangeCoefficients(signingIndices, params.Order)
+
+	nonceSecret, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var aggregateR tedwards.PointAffine
+	aggregateR.ScalarMultiplication(&params.Base, nonceSecret)
+
+	msgScalar, err := cryptorand.Int(cryptorand.Reader, snarkField)
+	if err != nil {
+		log.Fatal(err)
+	}
+	msg := *msgScalar
+
+	// Challenge c = MiMC(R.X, R.Y, Y.X, Y.Y, M), the same formula
+	// ThresholdEdDSACircuit.Define and ThresholdEdDSAPartialCircuit.Define
+	// compute in-circuit via mimc.MiMC.Write/Sum over that exact sequence.
+	c := mimcHashLimbs(
+		aggregateR.X.BigInt(new(big.Int)), aggregateR.Y.BigInt(new(big.Int)),
+		groupKey.X.BigInt(new(big.Int)), groupKey.Y.BigInt(new(big.Int)),
+		msgScalar,
+	)
+
+	// Aggregate s = nonceSecret + c * Σ λ_i * share_i = nonceSecret + c*groupSecret
+	aggregateS := new(big.Int).Mul(c, groupSecret)
+	aggregateS.Add(aggregateS, nonceSecret)
+	aggregateS.Mod(aggregateS, params.Order)
+
+	circuit := ThresholdEdDSACircuit{
+		curveID: curve,
+		Shares:  make([]stdeddsa.PublicKey, t),
+		Lambdas: make([]frontend.Variable, t),
+	}
+
+	assignment := ThresholdEdDSACircuit{
+		Message: msg,
+		Shares:  make([]stdeddsa.PublicKey, t),
+		Lambdas: make([]frontend.Variable, t),
+	}
+	assignment.GroupKey.A.X = groupKey.X
+	assignment.GroupKey.A.Y = groupKey.Y
+	assignment.Signature.R.X = aggregateR.X
+	assignment.Signature.R.Y = aggregateR.Y
+	assignment.Signature.S = aggregateS
+	for i, idx := range signingIndices {
+		var share tedwards.PointAffine
+		share.ScalarMultiplication(&params.Base, secretShares[idx-1])
+		assignment.Shares[i].A.X = share.X
+		assignment.Shares[i].A.Y = share.Y
+		assignment.Lambdas[i] = lambdas[i]
+	}
+
+	r1csSystem, err := frontend.Compile(snarkField, r1cs.NewBuilder, &circuit)
+	if err != nil {
+		log.Fatal("Failed to compile circuit! ", err)
+	}
+
+	pk, vk, err := groth16.Setup(r1csSystem)
+	if err != nil {
+		log.Fatal("Failed to perform setup! ", err)
+	}
+
+	witness, err := frontend.NewWitness(&assignment, snarkField)
+	if err != nil {
+		log.Fatal("Failed to generate witness! ", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		log.Fatal("Failed to obtain public witness! ", err)
+	}
+
+	proof, err := groth16.Prove(r1csSystem, pk, witness)
+	if err != nil {
+		log.Fatal("Failed to generate proof! ", err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		log.Fatal("Failed to verify proof! ", err)
+	}
+}
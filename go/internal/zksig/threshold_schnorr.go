@@ -0,0 +1,176 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"log"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+)
+
+// ThresholdSchnorrCircuit is ThresholdEdDSACircuit's counterpart over the
+// same emulated short Weierstrass curve family SchnorrCircuit uses: the
+// group key is reconstructed in-circuit as Σ λ_i·Y_i from the signing
+// subset's verification shares and Lagrange coefficients, then the
+// ordinary Schnorr equation is checked against the reconstructed key, so a
+// verifier learns that a valid (t,n) quorum signed without learning which
+// participants were in the subset.
+type ThresholdSchnorrCircuit[T, S emulated.FieldParams] struct {
+	curveID ecc.ID `gnark:",secret"`
+
+	GroupKey  stdecdsa.PublicKey[T, S] `gnark:",public"`
+	Signature SchnorrSignature[T, S]   `gnark:",public"`
+	Message   []uints.U8               `gnark:",public"`
+
+	// Shares/Lambdas are the signing subset's verification shares Y_i and
+	// their Lagrange coefficients λ_i, kept secret so the verifier cannot
+	// tell which participants signed.
+	Shares  []stdecdsa.PublicKey[T, S] `gnark:",secret"`
+	Lambdas []emulated.Element[S]      `gnark:",secret"`
+}
+
+// Define declares the circuit constraints: reconstruct the group key via
+// the weighted sum Σ λ_i·Y_i, assert it matches the public GroupKey, and
+// run the SchnorrCircuit verification equation against it.
+func (circuit *ThresholdSchnorrCircuit[T, S]) Define(api frontend.API) error {
+	if len(circuit.Shares) != len(circuit.Lambdas) {
+		return errThresholdWitnessMismatch
+	}
+	if len(circuit.Shares) == 0 {
+		return errThresholdEmptySubset
+	}
+
+	curve, err := sw_emulated.New[T, S](api, sw_emulated.GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+
+	reconstructed := weightedSumEmulated(curve, circuit.Shares, circuit.Lambdas)
+	curve.AssertIsEqual(reconstructed, &sw_emulated.AffinePoint[T]{X: circuit.GroupKey.X, Y: circuit.GroupKey.Y})
+
+	// Same challenge SchnorrCircuit.Define computes, over GroupKey instead
+	// of PublicKey, kept in one place so the two circuits can't diverge.
+	e, err := schnorrChallenge[T, S](api, circuit.Message, circuit.Signature.R.X, circuit.Signature.R.Y, circuit.GroupKey.X, circuit.GroupKey.Y)
+	if err != nil {
+		return err
+	}
+
+	r := &sw_emulated.AffinePoint[T]{X: circuit.Signature.R.X, Y: circuit.Signature.R.Y}
+	lhs := curve.ScalarMulBase(&circuit.Signature.S)
+	rhs := curve.AddUnified(r, curve.ScalarMul(&sw_emulated.AffinePoint[T]{X: circuit.GroupKey.X, Y: circuit.GroupKey.Y}, e))
+	curve.AssertIsEqual(lhs, rhs)
+
+	return nil
+}
+
+// weightedSumEmulated computes Σ shares_i·lambdas_i over the emulated
+// curve, the non-native analogue of threshold.go's weightedSum.
+func weightedSumEmulated[T, S emulated.FieldParams](curve *sw_emulated.Curve[T, S], shares []stdecdsa.PublicKey[T, S], lambdas []emulated.Element[S]) *sw_emulated.AffinePoint[T] {
+	sum := curve.ScalarMul(&sw_emulated.AffinePoint[T]{X: shares[0].X, Y: shares[0].Y}, &lambdas[0])
+	for i := 1; i < len(shares); i++ {
+		term := curve.ScalarMul(&sw_emulated.AffinePoint[T]{X: shares[i].X, Y: shares[i].Y}, &lambdas[i])
+		sum = curve.AddUnified(sum, term)
+	}
+	return sum
+}
+
+// RunThresholdSchnorr mirrors RunThresholdEdDSA: it Shamir-shares a group
+// signing key among n participants over secp256k1, signs the message with
+// the group secret those shares reconstruct, and proves - via
+// ThresholdSchnorrCircuit, from the verification shares and Lagrange
+// coefficients of a t-participant subset - that the signature verifies
+// under the group key that subset reconstructs, without revealing which
+// participants were in it. The participants do not compute partial
+// signatures here.
+func RunThresholdSchnorr() {
+	const curve = ecc.BN254
+	const t, n = 3, 5
+	snarkField := curve.ScalarField()
+	order := fr.Modulus()
+
+	_, _, g1Gen, _ := secp256k1.Generators()
+
+	groupSecret, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var groupKey secp256k1.G1Affine
+	groupKey.ScalarMultiplication(&g1Gen, groupSecret)
+
+	secretShares := shamirShares(groupSecret, t, n, order)
+
+	signingIndices := make([]int, t)
+	for i := range signingIndices {
+		signingIndices[i] = i + 1
+	}
+	lambdas := LagrangeCoefficients(signingIndices, order)
+
+	msgData := []byte("this is a threshold Schnorr test message")
+
+	// The aggregate signature is just a Schnorr signature under the
+	// reconstructed group secret: Σ λ_i·share_i == groupSecret.
+	r, s, err := signSchnorr(groupSecret, groupKey, msgData)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	circuit := ThresholdSchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		Shares:  make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], t),
+		Lambdas: make([]emulated.Element[emulated.Secp256k1Fr], t),
+	}
+
+	assignment := ThresholdSchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		GroupKey: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](groupKey),
+		Signature: SchnorrSignature[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			R: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Message: uints.NewU8Array(msgData),
+		Shares:  make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], t),
+		Lambdas: make([]emulated.Element[emulated.Secp256k1Fr], t),
+	}
+	for i, idx := range signingIndices {
+		var share secp256k1.G1Affine
+		share.ScalarMultiplication(&g1Gen, secretShares[idx-1])
+		assignment.Shares[i] = assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](share)
+		assignment.Lambdas[i] = emulated.ValueOf[emulated.Secp256k1Fr](lambdas[i])
+	}
+
+	r1csSystem, err := frontend.Compile(snarkField, r1cs.NewBuilder, &circuit)
+	if err != nil {
+		log.Fatal("Failed to compile circuit! ", err)
+	}
+
+	pk, vk, err := groth16.Setup(r1csSystem)
+	if err != nil {
+		log.Fatal("Failed to perform setup! ", err)
+	}
+
+	witness, err := frontend.NewWitness(&assignment, snarkField)
+	if err != nil {
+		log.Fatal("Failed to generate witness! ", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		log.Fatal("Failed to obtain public witness! ", err)
+	}
+
+	proof, err := groth16.Prove(r1csSystem, pk, witness)
+	if err != nil {
+		log.Fatal("Failed to generate proof! ", err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		log.Fatal("Failed to verify proof! ", err)
+	}
+}
+
@@ -0,0 +1,76 @@
+package zksig
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	stdecdsa "github.com/consensys/gnark/std/signature/ecdsa"
+	"github.com/consensys/gnark/test"
+)
+
+func TestThresholdSchnorr(t *testing.T) {
+	const curve = ecc.BN254
+	const tThreshold, n = 3, 5
+	snarkField := curve.ScalarField()
+	order := fr.Modulus()
+	_, _, g1Gen, _ := secp256k1.Generators()
+
+	groupSecret := bigIntOfSchnorr(t, 424242)
+	var groupKey secp256k1.G1Affine
+	groupKey.ScalarMultiplication(&g1Gen, groupSecret)
+
+	secretShares := shamirShares(groupSecret, tThreshold, n, order)
+
+	signingIndices := make([]int, tThreshold)
+	for i := range signingIndices {
+		signingIndices[i] = i + 1
+	}
+	lambdas := LagrangeCoefficients(signingIndices, order)
+
+	msgData := []byte("this is a threshold Schnorr test message")
+	r, s, err := signSchnorr(groupSecret, groupKey, msgData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifySchnorr(groupKey, r, s, msgData) {
+		t.Fatal("Invalid signature!")
+	}
+
+	circuit := ThresholdSchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		curveID: curve,
+		Message: make([]uints.U8, len(msgData)),
+		Shares:  make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], tThreshold),
+		Lambdas: make([]emulated.Element[emulated.Secp256k1Fr], tThreshold),
+	}
+
+	witnessAssignment := ThresholdSchnorrCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		GroupKey: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](groupKey),
+		Signature: SchnorrSignature[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			R: assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Message: uints.NewU8Array(msgData),
+		Shares:  make([]stdecdsa.PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], tThreshold),
+		Lambdas: make([]emulated.Element[emulated.Secp256k1Fr], tThreshold),
+	}
+	for i, idx := range signingIndices {
+		var share secp256k1.G1Affine
+		share.ScalarMultiplication(&g1Gen, secretShares[idx-1])
+		witnessAssignment.Shares[i] = assignSchnorrKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr](share)
+		witnessAssignment.Lambdas[i] = emulated.ValueOf[emulated.Secp256k1Fr](lambdas[i])
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func bigIntOfSchnorr(t *testing.T, v int64) *big.Int {
+	t.Helper()
+	return big.NewInt(v)
+}
@@ -0,0 +1,241 @@
+package zksig
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"testing"
+
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	stdeddsa "github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/consensys/gnark/test"
+)
+
+// TestLagrangeReconstruction checks that LagrangeCoefficients, applied to
+// shamirShares, reconstructs the shared secret from any t of the n
+// shares - the off-circuit half of ThresholdEdDSACircuit's group-key
+// reconstruction.
+func TestLagrangeReconstruction(t *testing.T) {
+	params, err := tedwards.GetCurveParams(tedwards.BN254)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := params.Order
+
+	secret, err := cryptorand.Int(cryptorand.Reader, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const threshold, total = 3, 5
+	shares := shamirShares(secret, threshold, total, order)
+
+	for _, subset := range [][]int{{1, 2, 3}, {2, 4, 5}, {1, 3, 5}} {
+		lambdas := LagrangeCoefficients(subset, order)
+
+		reconstructed := new(big.Int)
+		for i, idx := range subset {
+			term := new(big.Int).Mul(lambdas[i], shares[idx-1])
+			reconstructed.Add(reconstructed, term)
+			reconstructed.Mod(reconstructed, order)
+		}
+
+		if reconstructed.Cmp(secret) != 0 {
+			t.Fatalf("subset %v: reconstructed %s, want %s", subset, reconstructed, secret)
+		}
+	}
+}
+
+// TestThresholdEdDSA proves a genuine (t,n)-threshold aggregate signature
+// via ThresholdEdDSACircuit: the aggregate Signature (R, S) has
+// S = nonceSecret + c*groupSecret for c = MiMC(R, Y, M), the same formula
+// ThresholdEdDSACircuit.Define now derives itself rather than delegating
+// to stdeddsa.Verify.
+func TestThresholdEdDSA(t *testing.T) {
+	const curve = tedwards.BN254
+	const tThreshold, n = 3, 5
+
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupSecret, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var groupKey tedwards.PointAffine
+	groupKey.ScalarMultiplication(&params.Base, groupSecret)
+
+	secretShares := shamirShares(groupSecret, tThreshold, n, params.Order)
+
+	signingIndices := make([]int, tThreshold)
+	for i := range signingIndices {
+		signingIndices[i] = i + 1
+	}
+	lambdas := LagrangeCoefficients(signingIndices, params.Order)
+
+	nonceSecret, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aggregateR tedwards.PointAffine
+	aggregateR.ScalarMultiplication(&params.Base, nonceSecret)
+
+	msgScalar, err := cryptorand.Int(cryptorand.Reader, snarkField)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := *msgScalar
+
+	c := mimcHashLimbs(
+		aggregateR.X.BigInt(new(big.Int)), aggregateR.Y.BigInt(new(big.Int)),
+		groupKey.X.BigInt(new(big.Int)), groupKey.Y.BigInt(new(big.Int)),
+		msgScalar,
+	)
+
+	aggregateS := new(big.Int).Mul(c, groupSecret)
+	aggregateS.Add(aggregateS, nonceSecret)
+	aggregateS.Mod(aggregateS, params.Order)
+
+	circuit := ThresholdEdDSACircuit{
+		curveID: curve,
+		Shares:  make([]stdeddsa.PublicKey, tThreshold),
+		Lambdas: make([]frontend.Variable, tThreshold),
+	}
+
+	witnessAssignment := ThresholdEdDSACircuit{
+		Message: msg,
+		Shares:  make([]stdeddsa.PublicKey, tThreshold),
+		Lambdas: make([]frontend.Variable, tThreshold),
+	}
+	witnessAssignment.GroupKey.A.X = groupKey.X
+	witnessAssignment.GroupKey.A.Y = groupKey.Y
+	witnessAssignment.Signature.R.X = aggregateR.X
+	witnessAssignment.Signature.R.Y = aggregateR.Y
+	witnessAssignment.Signature.S = aggregateS
+	for i, idx := range signingIndices {
+		var share tedwards.PointAffine
+		share.ScalarMultiplication(&params.Base, secretShares[idx-1])
+		witnessAssignment.Shares[i].A.X = share.X
+		witnessAssignment.Shares[i].A.Y = share.Y
+		witnessAssignment.Lambdas[i] = lambdas[i]
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestThresholdEdDSAPartial proves that t genuine per-participant partial
+// signatures - each satisfying s_i*G == R_i + c*Y_i under the shared
+// challenge c = MiMC(AggregateR, Y, M) - combine into the public aggregate
+// (AggregateR, AggregateS) via ThresholdEdDSAPartialCircuit.
+func TestThresholdEdDSAPartial(t *testing.T) {
+	const curve = tedwards.BN254
+	const tThreshold, n = 3, 5
+
+	snarkField, err := twistededwards.GetSnarkField(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := tedwards.GetCurveParams(curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupSecret, err := cryptorand.Int(cryptorand.Reader, params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var groupKey tedwards.PointAffine
+	groupKey.ScalarMultiplication(&params.Base, groupSecret)
+
+	secretShares := shamirShares(groupSecret, tThreshold, n, params.Order)
+
+	signingIndices := make([]int, tThreshold)
+	for i := range signingIndices {
+		signingIndices[i] = i + 1
+	}
+	lambdas := LagrangeCoefficients(signingIndices, params.Order)
+
+	msgScalar, err := cryptorand.Int(cryptorand.Reader, snarkField)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := *msgScalar
+
+	// Each participant contributes its own nonce share; the aggregate
+	// nonce (and hence the challenge) is their sum.
+	nonceSecrets := make([]*big.Int, tThreshold)
+	nonceShares := make([]tedwards.PointAffine, tThreshold)
+	for i := range nonceSecrets {
+		ns, err := cryptorand.Int(cryptorand.Reader, params.Order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonceSecrets[i] = ns
+		nonceShares[i].ScalarMultiplication(&params.Base, ns)
+	}
+	aggregateR := nonceShares[0]
+	for i := 1; i < tThreshold; i++ {
+		aggregateR.Add(&aggregateR, &nonceShares[i])
+	}
+
+	c := mimcHashLimbs(
+		aggregateR.X.BigInt(new(big.Int)), aggregateR.Y.BigInt(new(big.Int)),
+		groupKey.X.BigInt(new(big.Int)), groupKey.Y.BigInt(new(big.Int)),
+		msgScalar,
+	)
+
+	partialSignatures := make([]*big.Int, tThreshold)
+	aggregateS := new(big.Int)
+	for i, idx := range signingIndices {
+		s := new(big.Int).Mul(c, secretShares[idx-1])
+		s.Add(s, nonceSecrets[i])
+		s.Mod(s, params.Order)
+		partialSignatures[i] = s
+
+		aggregateS.Add(aggregateS, new(big.Int).Mul(lambdas[i], s))
+		aggregateS.Mod(aggregateS, params.Order)
+	}
+
+	circuit := ThresholdEdDSAPartialCircuit{
+		curveID:           curve,
+		Shares:            make([]stdeddsa.PublicKey, tThreshold),
+		Lambdas:           make([]frontend.Variable, tThreshold),
+		NonceShares:       make([]twistededwards.Point, tThreshold),
+		PartialSignatures: make([]frontend.Variable, tThreshold),
+	}
+
+	witnessAssignment := ThresholdEdDSAPartialCircuit{
+		Message:           msg,
+		Shares:            make([]stdeddsa.PublicKey, tThreshold),
+		Lambdas:           make([]frontend.Variable, tThreshold),
+		NonceShares:       make([]twistededwards.Point, tThreshold),
+		PartialSignatures: make([]frontend.Variable, tThreshold),
+	}
+	witnessAssignment.GroupKey.A.X = groupKey.X
+	witnessAssignment.GroupKey.A.Y = groupKey.Y
+	witnessAssignment.AggregateR.X = aggregateR.X
+	witnessAssignment.AggregateR.Y = aggregateR.Y
+	witnessAssignment.AggregateS = aggregateS
+	for i, idx := range signingIndices {
+		var share tedwards.PointAffine
+		share.ScalarMultiplication(&params.Base, secretShares[idx-1])
+		witnessAssignment.Shares[i].A.X = share.X
+		witnessAssignment.Shares[i].A.Y = share.Y
+		witnessAssignment.Lambdas[i] = lambdas[i]
+		witnessAssignment.NonceShares[i] = twistededwards.Point{X: nonceShares[i].X, Y: nonceShares[i].Y}
+		witnessAssignment.PartialSignatures[i] = partialSignatures[i]
+	}
+
+	if err := test.IsSolved(&circuit, &witnessAssignment, snarkField); err != nil {
+		t.Fatal(err)
+	}
+}